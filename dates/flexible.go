@@ -0,0 +1,34 @@
+package dates
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// flexibleLayouts are tried in order by ParseFlexible, from the most to the least specific.
+// Fractional seconds are optional in every layout with a seconds field.
+var flexibleLayouts = []string{
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	string(DateLayoutYYYYMMDD),
+}
+
+// ParseFlexible parses a date or datetime that may carry an optional fractional-second suffix
+// and an optional timezone, e.g. "2006-01-02T15:04:05", "2006-01-02T15:04:05.123" or
+// "2006-01-02T15:04:05.123+02:00". Values without a timezone are interpreted as UTC.
+func ParseFlexible(in string) (time.Time, error) {
+	in = strings.TrimSpace(in)
+	for _, layout := range flexibleLayouts {
+		if t, err := time.Parse(layout, in); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("failed to parse date %q: no matching layout", in)
+}
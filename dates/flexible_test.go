@@ -0,0 +1,35 @@
+package dates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFlexible(t *testing.T) {
+	plus2 := time.FixedZone("", 2*60*60)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2024-08-07T12:30:45", time.Date(2024, 8, 7, 12, 30, 45, 0, time.UTC)},
+		{"2024-08-07T12:30:45.123", time.Date(2024, 8, 7, 12, 30, 45, 123000000, time.UTC)},
+		{"2024-08-07T12:30:45.123456789Z", time.Date(2024, 8, 7, 12, 30, 45, 123456789, time.UTC)},
+		{"2024-08-07T12:30:45+02:00", time.Date(2024, 8, 7, 12, 30, 45, 0, plus2)},
+		{"2024-08-07T12:30:45.5+0200", time.Date(2024, 8, 7, 12, 30, 45, 500000000, plus2)},
+		{"2024-08-07 12:30:45.1", time.Date(2024, 8, 7, 12, 30, 45, 100000000, time.UTC)},
+		{"2024-08-07T12:30", time.Date(2024, 8, 7, 12, 30, 0, 0, time.UTC)},
+		{"2024-08-07", time.Date(2024, 8, 7, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseFlexible(tt.in)
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+		})
+	}
+
+	_, err := ParseFlexible("07/08/2024")
+	assert.Error(t, err)
+}
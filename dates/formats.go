@@ -10,6 +10,8 @@ type DateLayout string
 const (
 	DateLayoutYYYYMMDD        DateLayout = "2006-01-02"
 	DateLayoutYYYYMMDDTHHMMSS DateLayout = "2006-01-02T15:04:05"
+	DateLayoutRFC3339         DateLayout = time.RFC3339
+	DateLayoutYYYYMMDDCompact DateLayout = "20060102"
 )
 
 func Parse(layout DateLayout, in string) (time.Time, error) {
@@ -0,0 +1,78 @@
+package dates
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// day is the length of a calendar day without daylight saving adjustments.
+const day = 24 * time.Hour
+
+type durationUnit struct {
+	size  time.Duration
+	name  string
+	short string
+}
+
+var durationUnits = []durationUnit{
+	{day, "day", "d"},
+	{time.Hour, "hour", "h"},
+	{time.Minute, "minute", "m"},
+	{time.Second, "second", "s"},
+}
+
+var subSecondUnits = []durationUnit{
+	{time.Millisecond, "millisecond", "ms"},
+	{time.Microsecond, "microsecond", "µs"},
+	{time.Nanosecond, "nanosecond", "ns"},
+}
+
+// HumanizeDuration formats d for display, e.g. "1 hour 30 minutes" or "2 days 5 seconds".
+// Zero components are dropped and units are pluralized. Durations of a second or more are truncated
+// to whole seconds, while shorter durations use the largest fitting sub-second unit ("250 milliseconds").
+func HumanizeDuration(d time.Duration) string {
+	return humanize(d, func(n int64, u durationUnit) string {
+		if n == 1 {
+			return fmt.Sprintf("%d %s", n, u.name)
+		}
+		return fmt.Sprintf("%d %ss", n, u.name)
+	})
+}
+
+// HumanizeDurationShort formats d compactly for display, e.g. "1h 30m" or "2d 5s".
+// It follows the same rules as HumanizeDuration.
+func HumanizeDurationShort(d time.Duration) string {
+	return humanize(d, func(n int64, u durationUnit) string {
+		return fmt.Sprintf("%d%s", n, u.short)
+	})
+}
+
+func humanize(d time.Duration, format func(n int64, u durationUnit) string) string {
+	// Work with the magnitude as a uint64, since negating math.MinInt64 overflows a Duration.
+	sign := ""
+	abs := uint64(d)
+	if d < 0 {
+		sign = "-"
+		abs = -abs
+	}
+
+	if abs < uint64(time.Second) {
+		for _, u := range subSecondUnits {
+			if abs >= uint64(u.size) {
+				return sign + format(int64(abs/uint64(u.size)), u)
+			}
+		}
+		return format(0, durationUnits[len(durationUnits)-1])
+	}
+
+	var parts []string
+	for _, u := range durationUnits {
+		if n := abs / uint64(u.size); n > 0 {
+			parts = append(parts, format(int64(n), u))
+			abs -= n * uint64(u.size)
+		}
+	}
+
+	return sign + strings.Join(parts, " ")
+}
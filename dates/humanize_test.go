@@ -0,0 +1,35 @@
+package dates
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanizeDuration(t *testing.T) {
+	tests := []struct {
+		in    time.Duration
+		long  string
+		short string
+	}{
+		{0, "0 seconds", "0s"},
+		{500 * time.Nanosecond, "500 nanoseconds", "500ns"},
+		{1500 * time.Microsecond, "1 millisecond", "1ms"},
+		{250 * time.Millisecond, "250 milliseconds", "250ms"},
+		{time.Second, "1 second", "1s"},
+		{90 * time.Minute, "1 hour 30 minutes", "1h 30m"},
+		{time.Hour + 1500*time.Millisecond, "1 hour 1 second", "1h 1s"},
+		{49*time.Hour + 5*time.Second, "2 days 1 hour 5 seconds", "2d 1h 5s"},
+		{-2 * time.Minute, "-2 minutes", "-2m"},
+		{math.MaxInt64, "106751 days 23 hours 47 minutes 16 seconds", "106751d 23h 47m 16s"},
+		{math.MinInt64, "-106751 days 23 hours 47 minutes 16 seconds", "-106751d 23h 47m 16s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in.String(), func(t *testing.T) {
+			assert.Equal(t, tt.long, HumanizeDuration(tt.in))
+			assert.Equal(t, tt.short, HumanizeDurationShort(tt.in))
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package dates
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Layouts are the layouts tried in order by ParseAny. Callers may append to or reorder it
+// to teach ParseAny about other formats; the compact date-only layout comes last as a fallback.
+var Layouts = []DateLayout{
+	DateLayoutYYYYMMDD,
+	DateLayoutYYYYMMDDTHHMMSS,
+	DateLayoutRFC3339,
+	DateLayoutYYYYMMDDCompact,
+}
+
+// ParseAny parses in with the first entry of Layouts that accepts it and returns the layout that matched.
+// When no layout matches, the error lists every layout that was attempted.
+func ParseAny(in string) (time.Time, DateLayout, error) {
+	attempted := make([]string, 0, len(Layouts))
+	for _, layout := range Layouts {
+		if t, err := time.Parse(string(layout), in); err == nil {
+			return t, layout, nil
+		}
+		attempted = append(attempted, string(layout))
+	}
+	return time.Time{}, "", fmt.Errorf("failed to parse date %q: tried layouts %s", in, strings.Join(attempted, ", "))
+}
@@ -0,0 +1,43 @@
+package dates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAny(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       time.Time
+		wantLayout DateLayout
+	}{
+		{"2024-08-07", time.Date(2024, 8, 7, 0, 0, 0, 0, time.UTC), DateLayoutYYYYMMDD},
+		{"2024-08-07T10:30:00", time.Date(2024, 8, 7, 10, 30, 0, 0, time.UTC), DateLayoutYYYYMMDDTHHMMSS},
+		{"2024-08-07T10:30:00Z", time.Date(2024, 8, 7, 10, 30, 0, 0, time.UTC), DateLayoutRFC3339},
+		{"20240807", time.Date(2024, 8, 7, 0, 0, 0, 0, time.UTC), DateLayoutYYYYMMDDCompact},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, layout, err := ParseAny(tt.in)
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %v", got)
+			assert.Equal(t, tt.wantLayout, layout)
+		})
+	}
+
+	_, _, err := ParseAny("08/07/2024")
+	assert.ErrorContains(t, err, "2006-01-02, 2006-01-02T15:04:05, "+time.RFC3339+", 20060102")
+}
+
+func TestParseAnyCustomLayouts(t *testing.T) {
+	defer func(saved []DateLayout) { Layouts = saved }(Layouts)
+	Layouts = append([]DateLayout{"01/02/2006"}, Layouts...)
+
+	got, layout, err := ParseAny("08/07/2024")
+	assert.NoError(t, err)
+	assert.Equal(t, DateLayout("01/02/2006"), layout)
+	assert.Equal(t, time.Date(2024, 8, 7, 0, 0, 0, 0, time.UTC), got)
+}
@@ -0,0 +1,47 @@
+package dates
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unixMilliThreshold is the magnitude at or above which ParseUnixString treats a timestamp as milliseconds.
+// 1e11 seconds is in the year 5138 while 1e11 milliseconds is in 1973, so real-world values are unambiguous.
+const unixMilliThreshold = 1e11
+
+// FromUnix returns the local time corresponding to sec seconds since the unix epoch.
+func FromUnix(sec int64) time.Time {
+	return time.Unix(sec, 0)
+}
+
+// FromUnixMilli returns the local time corresponding to ms milliseconds since the unix epoch.
+func FromUnixMilli(ms int64) time.Time {
+	return time.UnixMilli(ms)
+}
+
+// ToUnix returns t as seconds since the unix epoch.
+func ToUnix(t time.Time) int64 {
+	return t.Unix()
+}
+
+// ToUnixMilli returns t as milliseconds since the unix epoch.
+func ToUnixMilli(t time.Time) int64 {
+	return t.UnixMilli()
+}
+
+// ParseUnixString parses a unix timestamp string, detecting seconds vs milliseconds by magnitude.
+// Values whose absolute value is at least 1e11 are treated as milliseconds, anything smaller as seconds.
+func ParseUnixString(s string) (time.Time, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse unix timestamp: %w", err)
+	}
+
+	if n >= unixMilliThreshold || n <= -unixMilliThreshold {
+		return FromUnixMilli(n), nil
+	}
+
+	return FromUnix(n), nil
+}
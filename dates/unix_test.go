@@ -0,0 +1,41 @@
+package dates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnixConversions(t *testing.T) {
+	ts := time.Date(2024, 8, 7, 12, 0, 0, 500*int(time.Millisecond), time.UTC)
+
+	assert.Equal(t, int64(1723032000), ToUnix(ts))
+	assert.Equal(t, int64(1723032000500), ToUnixMilli(ts))
+	assert.True(t, FromUnix(1723032000).Equal(ts.Truncate(time.Second)))
+	assert.True(t, FromUnixMilli(1723032000500).Equal(ts))
+}
+
+func TestParseUnixString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"1723032000", time.Unix(1723032000, 0)},
+		{"1723032000500", time.UnixMilli(1723032000500)},
+		{"99999999999", time.Unix(99999999999, 0)},
+		{"100000000000", time.UnixMilli(100000000000)},
+		{"-100000000000", time.UnixMilli(-100000000000)},
+		{" 0 ", time.Unix(0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseUnixString(tt.in)
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+		})
+	}
+
+	_, err := ParseUnixString("yesterday")
+	assert.Error(t, err)
+}
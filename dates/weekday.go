@@ -0,0 +1,23 @@
+package dates
+
+import "time"
+
+// NextWeekday returns the next date strictly after from that falls on wd.
+// If from is already on wd, the result is one week later. The time of day is preserved.
+func NextWeekday(from time.Time, wd time.Weekday) time.Time {
+	days := (int(wd) - int(from.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return from.AddDate(0, 0, days)
+}
+
+// PreviousWeekday returns the last date strictly before from that falls on wd.
+// If from is already on wd, the result is one week earlier. The time of day is preserved.
+func PreviousWeekday(from time.Time, wd time.Weekday) time.Time {
+	days := (int(from.Weekday()) - int(wd) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return from.AddDate(0, 0, -days)
+}
@@ -0,0 +1,25 @@
+package dates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextWeekday(t *testing.T) {
+	// 2024-08-07 is a Wednesday.
+	from := time.Date(2024, 8, 7, 15, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2024, 8, 9, 15, 30, 0, 0, time.UTC), NextWeekday(from, time.Friday))
+	assert.Equal(t, time.Date(2024, 8, 12, 15, 30, 0, 0, time.UTC), NextWeekday(from, time.Monday))
+	assert.Equal(t, time.Date(2024, 8, 14, 15, 30, 0, 0, time.UTC), NextWeekday(from, time.Wednesday))
+}
+
+func TestPreviousWeekday(t *testing.T) {
+	from := time.Date(2024, 8, 7, 15, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2024, 8, 5, 15, 30, 0, 0, time.UTC), PreviousWeekday(from, time.Monday))
+	assert.Equal(t, time.Date(2024, 8, 2, 15, 30, 0, 0, time.UTC), PreviousWeekday(from, time.Friday))
+	assert.Equal(t, time.Date(2024, 7, 31, 15, 30, 0, 0, time.UTC), PreviousWeekday(from, time.Wednesday))
+}
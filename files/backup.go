@@ -0,0 +1,40 @@
+package files
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+// backupTimeFormat is the timestamp appended to backup file names; it sorts chronologically.
+const backupTimeFormat = "20060102T150405.000000000"
+
+// Backup copies the file at path to "<path>.bak.<timestamp>" in the same directory, preserving its mode.
+// It returns the path of the backup so it can later be passed to RestoreBackup.
+func Backup(path string) (backupPath string, err error) {
+	backupPath = path + ".bak." + time.Now().UTC().Format(backupTimeFormat)
+	if err := CopyFile(path, backupPath); err != nil {
+		return "", err
+	}
+	return backupPath, nil
+}
+
+// RestoreBackup atomically replaces originalPath with the contents and mode of backupPath.
+// The backup itself is left in place.
+func RestoreBackup(backupPath, originalPath string) error {
+	backup, err := os.Open(backupPath)
+	if err != nil {
+		return err
+	}
+	defer backup.Close()
+
+	stat, err := backup.Stat()
+	if err != nil {
+		return err
+	}
+
+	return writeAtomicFunc(originalPath, stat.Mode().Perm(), func(w io.Writer) error {
+		_, err := io.Copy(w, backup)
+		return err
+	})
+}
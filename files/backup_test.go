@@ -0,0 +1,37 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("version: 1\n"), 0600))
+
+	backupPath, err := Backup(path)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Dir(path), filepath.Dir(backupPath))
+	assert.True(t, strings.HasPrefix(backupPath, path+".bak."))
+
+	b, err := os.ReadFile(backupPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "version: 1\n", string(b))
+	info, err := os.Stat(backupPath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	assert.NoError(t, os.WriteFile(path, []byte("version: 2\n"), 0644))
+	assert.NoError(t, RestoreBackup(backupPath, path))
+
+	b, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "version: 1\n", string(b))
+	info, err = os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
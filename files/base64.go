@@ -0,0 +1,123 @@
+package files
+
+import (
+	"bufio"
+	"encoding/base64"
+	"io"
+	"os"
+)
+
+// DefaultBase64LineWidth is the line width used by Base64EncodeFile, matching MIME.
+const DefaultBase64LineWidth = 76
+
+// Base64EncodeFile streams src through a standard base64 encoder into dst,
+// wrapping lines at DefaultBase64LineWidth characters.
+func Base64EncodeFile(src, dst string) error {
+	return Base64EncodeFileWidth(src, dst, DefaultBase64LineWidth)
+}
+
+// Base64EncodeFileWidth streams src through a standard base64 encoder into dst,
+// wrapping lines at width characters. A width of 0 or less disables wrapping.
+func Base64EncodeFileWidth(src, dst string, width int) error {
+	sourceFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+
+	destinationFile, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer destinationFile.Close()
+
+	buffered := bufio.NewWriter(destinationFile)
+	wrapper := &lineWrapper{w: buffered, width: width}
+	encoder := base64.NewEncoder(base64.StdEncoding, wrapper)
+
+	if _, err := io.Copy(encoder, sourceFile); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	if err := wrapper.finish(); err != nil {
+		return err
+	}
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+
+	return destinationFile.Close()
+}
+
+// Base64DecodeFile streams the base64 encoded src through a decoder into dst.
+// Line breaks in src are ignored, so wrapped and unwrapped input are both accepted.
+func Base64DecodeFile(src, dst string) error {
+	sourceFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+
+	destinationFile, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer destinationFile.Close()
+
+	decoder := base64.NewDecoder(base64.StdEncoding, bufio.NewReader(sourceFile))
+	if _, err := io.Copy(destinationFile, decoder); err != nil {
+		return err
+	}
+
+	return destinationFile.Close()
+}
+
+// lineWrapper inserts a newline into the stream every width bytes.
+type lineWrapper struct {
+	w     io.Writer
+	width int
+	col   int
+}
+
+func (l *lineWrapper) Write(p []byte) (int, error) {
+	if l.width <= 0 {
+		return l.w.Write(p)
+	}
+
+	written := 0
+	for len(p) > 0 {
+		n := l.width - l.col
+		if n > len(p) {
+			n = len(p)
+		}
+
+		m, err := l.w.Write(p[:n])
+		written += m
+		if err != nil {
+			return written, err
+		}
+
+		l.col += n
+		p = p[n:]
+
+		if l.col == l.width {
+			if _, err := l.w.Write([]byte{'\n'}); err != nil {
+				return written, err
+			}
+			l.col = 0
+		}
+	}
+
+	return written, nil
+}
+
+// finish terminates a partially filled final line.
+func (l *lineWrapper) finish() error {
+	if l.width <= 0 || l.col == 0 {
+		return nil
+	}
+	_, err := l.w.Write([]byte{'\n'})
+	return err
+}
@@ -0,0 +1,36 @@
+package files
+
+import (
+	"bytes"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBase64RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "data.bin")
+	encoded := filepath.Join(dir, "data.b64")
+	decoded := filepath.Join(dir, "data.out")
+
+	data := make([]byte, 10000)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(src, data, 0644))
+
+	assert.NoError(t, Base64EncodeFile(src, encoded))
+	text, err := os.ReadFile(encoded)
+	assert.NoError(t, err)
+	for _, line := range strings.Split(strings.TrimSuffix(string(text), "\n"), "\n") {
+		assert.LessOrEqual(t, len(line), DefaultBase64LineWidth)
+	}
+
+	assert.NoError(t, Base64DecodeFile(encoded, decoded))
+	out, err := os.ReadFile(decoded)
+	assert.NoError(t, err)
+	assert.True(t, bytes.Equal(data, out))
+}
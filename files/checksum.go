@@ -0,0 +1,231 @@
+package files
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// WriteChecksumFile writes a manifest of every regular file under root to outPath
+// in the format produced by `sha256sum` ("<hex>  <path>"), with paths relative to root.
+// The manifest can be checked with `sha256sum -c` from within root.
+// If outPath is located under root it is excluded from the manifest.
+func WriteChecksumFile(root, outPath string) error {
+	absOut, err := filepath.Abs(outPath)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if abs, err := filepath.Abs(path); err == nil && abs == absOut {
+			return nil
+		}
+
+		sum, err := SHA256(path)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		lines = append(lines, fmt.Sprintf("%s  %s\n", sum, filepath.ToSlash(rel)))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeAtomicFunc(outPath, 0644, func(w io.Writer) error {
+		for _, line := range lines {
+			if _, err := io.WriteString(w, line); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// VerifyChecksumFile checks the files under root against a manifest in `sha256sum` format.
+// It returns the paths (as written in the manifest) whose content does not match or that are missing.
+// An error is returned only if the manifest cannot be read or is malformed.
+func VerifyChecksumFile(root, checksumPath string) ([]string, error) {
+	file, err := os.Open(checksumPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var mismatched []string
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		expected, path, ok := strings.Cut(line, "  ")
+		if !ok {
+			// sha256sum marks binary mode with "<hex> *<path>".
+			expected, path, ok = strings.Cut(line, " *")
+		}
+		if !ok {
+			return nil, fmt.Errorf("malformed checksum line %d in %s", lineNum, checksumPath)
+		}
+
+		actual, err := SHA256(filepath.Join(root, filepath.FromSlash(path)))
+		if err != nil || !strings.EqualFold(actual, expected) {
+			mismatched = append(mismatched, path)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return mismatched, nil
+}
+
+// HashDir returns a single SHA256 hex digest over the tree rooted at root.
+// Every entry contributes its relative path (in sorted order) along with the content of regular files
+// or the target string of symlinks, so any change to a file or to the tree structure changes the digest.
+func HashDir(root string) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		switch {
+		case d.IsDir():
+			fmt.Fprintf(h, "d\x00%s\n", rel)
+		case d.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "l\x00%s\x00%s\n", rel, target)
+		case d.Type().IsRegular():
+			sum, err := SHA256(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "f\x00%s\x00%s\n", rel, sum)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// CacheKey returns a SHA256 hex digest over the path, size and modification time of each file in paths.
+// Content is not read, so the key is cheap to compute and changes whenever any input file is modified.
+// The paths are sorted first so the key does not depend on argument order. Missing files produce an error.
+func CacheKey(paths ...string) (string, error) {
+	return cacheKey(paths, func(path string) (string, error) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d\x00%d", info.Size(), info.ModTime().UnixNano()), nil
+	})
+}
+
+// CacheKeyContent is like CacheKey but hashes each file's content instead of its size and modification time,
+// so the key survives touches that don't change content at the cost of reading every file.
+func CacheKeyContent(paths ...string) (string, error) {
+	return cacheKey(paths, func(path string) (string, error) {
+		return SHA256(path)
+	})
+}
+
+// cacheKey hashes each sorted path together with the fingerprint returned by describe.
+func cacheKey(paths []string, describe func(path string) (string, error)) (string, error) {
+	sorted := append([]string(nil), paths...)
+	sort.Strings(sorted)
+
+	h := sha256.New()
+	for _, path := range sorted {
+		fingerprint, err := describe(path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%s\n", path, fingerprint)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Checksum streams the file at path through h and returns the lowercase hex digest.
+// The file is never loaded fully into memory, so it is safe to use on large files.
+func Checksum(path string, h hash.Hash) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// MD5 returns the lowercase hex MD5 digest of the file at path.
+func MD5(path string) (string, error) {
+	return Checksum(path, md5.New())
+}
+
+// SHA256 returns the lowercase hex SHA256 digest of the file at path.
+func SHA256(path string) (string, error) {
+	return Checksum(path, sha256.New())
+}
+
+// SameContent reports whether the files at a and b have identical content.
+// Files of different sizes are reported as different without being read.
+func SameContent(a, b string) (bool, error) {
+	sizeA, sizeB := GetFileSize(a), GetFileSize(b)
+	if sizeA >= 0 && sizeB >= 0 && sizeA != sizeB {
+		return false, nil
+	}
+
+	sumA, err := SHA256(a)
+	if err != nil {
+		return false, err
+	}
+
+	sumB, err := SHA256(b)
+	if err != nil {
+		return false, err
+	}
+
+	return sumA == sumB, nil
+}
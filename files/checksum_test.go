@@ -0,0 +1,146 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksumFile(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "sub"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "a.txt"), []byte("alpha"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "sub", "b.txt"), []byte("beta"), 0644))
+
+	manifest := filepath.Join(root, "SHA256SUMS")
+	assert.NoError(t, WriteChecksumFile(root, manifest))
+
+	b, err := os.ReadFile(manifest)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), "8ed3f6ad685b959ead7022518e1af76cd816f8e8ec7ccdda1ed4018e8f2223f8  a.txt\n")
+	assert.Contains(t, string(b), "  sub/b.txt\n")
+
+	mismatched, err := VerifyChecksumFile(root, manifest)
+	assert.NoError(t, err)
+	assert.Empty(t, mismatched)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "sub", "b.txt"), []byte("tampered"), 0644))
+	mismatched, err = VerifyChecksumFile(root, manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sub/b.txt"}, mismatched)
+}
+
+func TestHashDir(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "sub"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "a.txt"), []byte("alpha"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "sub", "b.txt"), []byte("beta"), 0644))
+	assert.NoError(t, os.Symlink("a.txt", filepath.Join(root, "link")))
+
+	first, err := HashDir(root)
+	assert.NoError(t, err)
+
+	again, err := HashDir(root)
+	assert.NoError(t, err)
+	assert.Equal(t, first, again)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "sub", "b.txt"), []byte("betA"), 0644))
+	modified, err := HashDir(root)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, modified)
+
+	assert.NoError(t, os.Mkdir(filepath.Join(root, "empty"), 0755))
+	restructured, err := HashDir(root)
+	assert.NoError(t, err)
+	assert.NotEqual(t, modified, restructured)
+}
+
+func TestCacheKey(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.go")
+	b := filepath.Join(dir, "b.go")
+	assert.NoError(t, os.WriteFile(a, []byte("package a"), 0644))
+	assert.NoError(t, os.WriteFile(b, []byte("package b"), 0644))
+
+	key, err := CacheKey(a, b)
+	assert.NoError(t, err)
+	again, err := CacheKey(b, a)
+	assert.NoError(t, err)
+	assert.Equal(t, key, again)
+
+	later := time.Now().Add(time.Hour)
+	assert.NoError(t, os.Chtimes(b, later, later))
+	touched, err := CacheKey(a, b)
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, touched)
+
+	_, err = CacheKey(a, filepath.Join(dir, "missing.go"))
+	assert.Error(t, err)
+}
+
+func TestCacheKeyContent(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.go")
+	assert.NoError(t, os.WriteFile(a, []byte("package a"), 0644))
+
+	key, err := CacheKeyContent(a)
+	assert.NoError(t, err)
+
+	later := time.Now().Add(time.Hour)
+	assert.NoError(t, os.Chtimes(a, later, later))
+	touched, err := CacheKeyContent(a)
+	assert.NoError(t, err)
+	assert.Equal(t, key, touched)
+
+	assert.NoError(t, os.WriteFile(a, []byte("package b"), 0644))
+	modified, err := CacheKeyContent(a)
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, modified)
+}
+
+func TestChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hello.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("hello"), 0644))
+
+	sum, err := MD5(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "5d41402abc4b2a76b9719d911017c592", sum)
+
+	sum, err = SHA256(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", sum)
+
+	_, err = SHA256(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestSameContent(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	assert.NoError(t, os.WriteFile(src, []byte("hello, world"), 0644))
+
+	dst := filepath.Join(dir, "dst.txt")
+	assert.NoError(t, CopyFile(src, dst))
+
+	same, err := SameContent(src, dst)
+	assert.NoError(t, err)
+	assert.True(t, same)
+
+	other := filepath.Join(dir, "other.txt")
+	assert.NoError(t, os.WriteFile(other, []byte("hello, WORLD"), 0644))
+	same, err = SameContent(src, other)
+	assert.NoError(t, err)
+	assert.False(t, same)
+
+	shorter := filepath.Join(dir, "shorter.txt")
+	assert.NoError(t, os.WriteFile(shorter, []byte("hello"), 0644))
+	same, err = SameContent(src, shorter)
+	assert.NoError(t, err)
+	assert.False(t, same)
+
+	_, err = SameContent(src, filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,40 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// EachChunk streams the file at path in blocks of chunkSize bytes and calls fn for each block.
+// Every block is full except possibly the last. Iteration stops at the first error returned by fn.
+//
+// The chunk buffer is reused between calls, so fn must copy the bytes if it retains them.
+func EachChunk(path string, chunkSize int, fn func(chunk []byte) error) error {
+	if chunkSize <= 0 {
+		return fmt.Errorf("invalid chunk size %d", chunkSize)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := io.ReadFull(file, buf)
+		if n > 0 {
+			if err := fn(buf[:n]); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package files
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEachChunk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.bin")
+	assert.NoError(t, os.WriteFile(path, make([]byte, 1000), 0644))
+
+	var total int
+	var sizes []int
+	err := EachChunk(path, 256, func(chunk []byte) error {
+		total += len(chunk)
+		sizes = append(sizes, len(chunk))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int(GetFileSize(path)), total)
+	assert.Equal(t, []int{256, 256, 256, 232}, sizes)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = EachChunk(path, 256, func(chunk []byte) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,173 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// CopyFile copies the contents of the src file to dst and sets dst's mode and modification time to match src.
+func CopyFile(src, dst string) error {
+	_, err := CopyFileN(src, dst)
+	return err
+}
+
+// CopyFileN copies the contents of the src file to dst and sets dst's mode and modification time to match src.
+// It returns the number of bytes copied, which is handy for logging and metrics.
+func CopyFileN(src, dst string) (written int64, err error) {
+	return copyFileWith(src, dst, func(w io.Writer, r io.Reader, size int64) (int64, error) {
+		return io.Copy(w, r)
+	})
+}
+
+// CopyFileProgress copies src to dst like CopyFile while calling fn with the running number of bytes
+// copied and the source size after each chunk. On success fn is always called a final time with
+// copied == total so progress bars reach 100%. A nil fn behaves exactly like CopyFile.
+func CopyFileProgress(src, dst string, fn func(copied, total int64)) error {
+	if fn == nil {
+		return CopyFile(src, dst)
+	}
+
+	// lastCopied and lastTotal hold the most recent report; -1 means nothing has been reported yet.
+	lastCopied, lastTotal := int64(-1), int64(-1)
+	report := func(copied, total int64) {
+		lastCopied, lastTotal = copied, total
+		fn(copied, total)
+	}
+
+	written, err := copyFileWith(src, dst, func(w io.Writer, r io.Reader, size int64) (int64, error) {
+		return CopyStream(w, r, StreamOptions{
+			OnProgress: func(copied int64) { report(copied, size) },
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// Report completion with copied == total, even if the source changed size while copying,
+	// unless the last chunk already did.
+	if lastCopied != written || lastTotal != written {
+		report(written, written)
+	}
+
+	return nil
+}
+
+// copyFileWith copies src to dst using copyFn, which receives the source size, then syncs dst
+// and sets its mode and modification time to match src.
+func copyFileWith(src, dst string, copyFn func(w io.Writer, r io.Reader, size int64) (int64, error)) (written int64, err error) {
+	sourceFile, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer sourceFile.Close()
+
+	stat, err := sourceFile.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	if dstStat, err := os.Stat(dst); err == nil && os.SameFile(stat, dstStat) {
+		// Opening dst would truncate the source before anything is read.
+		return 0, fmt.Errorf("cannot copy %s onto itself", src)
+	}
+
+	// Create dst with the source permissions up front so a copy of a private file is never readable by others.
+	destinationFile, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, stat.Mode().Perm())
+	if err != nil {
+		return 0, err
+	}
+	defer destinationFile.Close()
+
+	written, err = copyFn(destinationFile, sourceFile, stat.Size())
+	if err != nil {
+		return written, err
+	}
+
+	if err = destinationFile.Sync(); err != nil {
+		return written, err
+	}
+
+	if err = destinationFile.Chmod(stat.Mode()); err != nil {
+		return written, err
+	}
+
+	if err = destinationFile.Close(); err != nil {
+		return written, err
+	}
+
+	// Only the modification time is available portably, so it is used for the access time too.
+	err = os.Chtimes(dst, stat.ModTime(), stat.ModTime())
+	return written, err
+}
+
+// CopyFileIfNewer copies src to dst only when dst is missing, src has a newer modification time,
+// or both have the same modification time but different sizes.
+// It returns whether a copy took place.
+func CopyFileIfNewer(src, dst string) (copied bool, err error) {
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return false, err
+	}
+
+	dstInfo, err := os.Stat(dst)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	if err == nil {
+		newer := srcInfo.ModTime().After(dstInfo.ModTime())
+		resized := srcInfo.ModTime().Equal(dstInfo.ModTime()) && srcInfo.Size() != dstInfo.Size()
+		if !newer && !resized {
+			return false, nil
+		}
+	}
+
+	if err := CopyFile(src, dst); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CopyRange copies length bytes starting at offset from the src file into the dst file.
+// The data is streamed so the source is never loaded fully into memory.
+// If the range extends past the end of src, only the available bytes are copied.
+//
+// Arguments:
+//   - src: the path of the file to read from
+//   - dst: the path of the file to write to (created or truncated)
+//   - offset: the byte offset in src to start copying from
+//   - length: the maximum number of bytes to copy
+//
+// Returns:
+//   - an error if the files could not be opened or the copy failed
+func CopyRange(src, dst string, offset, length int64) error {
+	if offset < 0 || length < 0 {
+		return fmt.Errorf("invalid range: offset %d, length %d", offset, length)
+	}
+
+	sourceFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+
+	if _, err := sourceFile.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	destinationFile, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer destinationFile.Close()
+
+	_, err = io.CopyN(destinationFile, sourceFile, length)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return destinationFile.Close()
+}
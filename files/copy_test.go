@@ -0,0 +1,173 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyRange(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+	assert.NoError(t, os.WriteFile(src, []byte("0123456789"), 0644))
+
+	assert.NoError(t, CopyRange(src, dst, 3, 4))
+	b, err := os.ReadFile(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, "3456", string(b))
+
+	assert.NoError(t, CopyRange(src, dst, 8, 10))
+	b, err = os.ReadFile(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, "89", string(b))
+}
+
+func TestCopyFileN(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+	assert.NoError(t, os.WriteFile(src, []byte("hello, world"), 0640))
+
+	written, err := CopyFileN(src, dst)
+	assert.NoError(t, err)
+	assert.Equal(t, GetFileSize(src), written)
+
+	info, err := os.Stat(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+
+	b, err := os.ReadFile(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello, world", string(b))
+}
+
+func TestCopyFileIfNewer(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+	assert.NoError(t, os.WriteFile(src, []byte("new"), 0644))
+
+	copied, err := CopyFileIfNewer(src, dst)
+	assert.NoError(t, err)
+	assert.True(t, copied, "missing destination")
+
+	now := time.Now().Truncate(time.Second)
+	tests := []struct {
+		name    string
+		srcTime time.Time
+		dstTime time.Time
+		want    bool
+	}{
+		{"newer", now, now.Add(-time.Hour), true},
+		{"older", now.Add(-time.Hour), now, false},
+		{"equal", now, now, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, os.WriteFile(dst, []byte("old"), 0644))
+			assert.NoError(t, os.Chtimes(src, tt.srcTime, tt.srcTime))
+			assert.NoError(t, os.Chtimes(dst, tt.dstTime, tt.dstTime))
+
+			copied, err := CopyFileIfNewer(src, dst)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, copied)
+
+			b, err := os.ReadFile(dst)
+			assert.NoError(t, err)
+			if tt.want {
+				assert.Equal(t, "new", string(b))
+			} else {
+				assert.Equal(t, "old", string(b))
+			}
+		})
+	}
+}
+
+func TestCopyFilePreservesModTime(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+	assert.NoError(t, os.WriteFile(src, []byte("content"), 0644))
+
+	modTime := time.Date(2020, 5, 6, 7, 8, 9, 0, time.UTC)
+	assert.NoError(t, os.Chtimes(src, modTime, modTime))
+
+	assert.NoError(t, CopyFile(src, dst))
+	info, err := os.Stat(dst)
+	assert.NoError(t, err)
+	assert.True(t, modTime.Equal(info.ModTime()), "got %v, want %v", info.ModTime(), modTime)
+}
+
+func TestCopyFileProgress(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "disk.img")
+	dst := filepath.Join(dir, "copy.img")
+	assert.NoError(t, os.WriteFile(src, make([]byte, 100*1024), 0600))
+
+	var calls [][2]int64
+	err := CopyFileProgress(src, dst, func(copied, total int64) {
+		calls = append(calls, [2]int64{copied, total})
+	})
+	assert.NoError(t, err)
+	assert.Greater(t, len(calls), 1)
+	assert.Equal(t, [2]int64{100 * 1024, 100 * 1024}, calls[len(calls)-1])
+	assert.NotEqual(t, calls[len(calls)-2], calls[len(calls)-1], "completion is reported once")
+	for i := 1; i < len(calls); i++ {
+		assert.GreaterOrEqual(t, calls[i][0], calls[i-1][0])
+	}
+
+	info, err := os.Stat(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	empty := filepath.Join(dir, "empty")
+	assert.NoError(t, os.WriteFile(empty, nil, 0644))
+	calls = nil
+	assert.NoError(t, CopyFileProgress(empty, filepath.Join(dir, "empty.copy"), func(copied, total int64) {
+		calls = append(calls, [2]int64{copied, total})
+	}))
+	assert.Equal(t, [][2]int64{{0, 0}}, calls)
+
+	assert.NoError(t, CopyFileProgress(src, filepath.Join(dir, "nil.img"), nil))
+}
+
+func TestCopyFilePrivateDuringCopy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix permissions")
+	}
+	dir := t.TempDir()
+	src := filepath.Join(dir, "secret.key")
+	dst := filepath.Join(dir, "secret.copy")
+	assert.NoError(t, os.WriteFile(src, make([]byte, 100*1024), 0600))
+
+	var modes []os.FileMode
+	assert.NoError(t, CopyFileProgress(src, dst, func(copied, total int64) {
+		info, err := os.Stat(dst)
+		assert.NoError(t, err)
+		modes = append(modes, info.Mode().Perm())
+	}))
+	assert.NotEmpty(t, modes)
+	for _, mode := range modes {
+		assert.Equal(t, os.FileMode(0600), mode)
+	}
+}
+
+func TestCopyFileOntoItself(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	assert.NoError(t, os.WriteFile(src, []byte("keep me"), 0644))
+
+	assert.Error(t, CopyFile(src, src))
+	if err := os.Link(src, filepath.Join(dir, "hard.txt")); err == nil {
+		assert.Error(t, CopyFile(src, filepath.Join(dir, "hard.txt")))
+	}
+
+	b, err := os.ReadFile(src)
+	assert.NoError(t, err)
+	assert.Equal(t, "keep me", string(b))
+}
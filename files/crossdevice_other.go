@@ -0,0 +1,13 @@
+//go:build !windows
+
+package files
+
+import (
+	"errors"
+	"syscall"
+)
+
+// isCrossDevice reports whether err is a rename failure caused by src and dst being on different devices.
+func isCrossDevice(err error) bool {
+	return errors.Is(err, syscall.EXDEV)
+}
@@ -0,0 +1,16 @@
+//go:build windows
+
+package files
+
+import (
+	"errors"
+	"syscall"
+)
+
+// errorNotSameDevice is returned by MoveFileEx when renaming across volumes.
+const errorNotSameDevice syscall.Errno = 17
+
+// isCrossDevice reports whether err is a rename failure caused by src and dst being on different volumes.
+func isCrossDevice(err error) bool {
+	return errors.Is(err, errorNotSameDevice) || errors.Is(err, syscall.EXDEV)
+}
@@ -0,0 +1,82 @@
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLFromFile reads the file at path and unmarshals its YAML content into a new T.
+// Gzip-compressed files (a ".gz" extension or the gzip magic header) are decompressed transparently.
+func YAMLFromFile[T any](path string) (*T, error) {
+	data, err := readMaybeGzip(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeYAML[T](path, data)
+}
+
+// JSONFromFile reads the file at path and unmarshals its JSON content into a new T.
+// Gzip-compressed files (a ".gz" extension or the gzip magic header) are decompressed transparently.
+func JSONFromFile[T any](path string) (*T, error) {
+	data, err := readMaybeGzip(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeJSON[T](path, data)
+}
+
+// decodeYAML unmarshals data read from path into a new T.
+func decodeYAML[T any](path string, data []byte) (*T, error) {
+	var t T
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to decode yaml from %s: %w", path, err)
+	}
+
+	return &t, nil
+}
+
+// decodeJSON unmarshals data read from path into a new T.
+func decodeJSON[T any](path string, data []byte) (*T, error) {
+	var t T
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to decode json from %s: %w", path, err)
+	}
+
+	return &t, nil
+}
+
+// gzipMagic is the header every gzip stream starts with.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// readMaybeGzip reads the file at path, gunzipping it when it has a ".gz" extension or starts with the gzip magic header.
+func readMaybeGzip(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	magic, _ := reader.Peek(len(gzipMagic))
+	if !strings.HasSuffix(path, ".gz") && !bytes.Equal(magic, gzipMagic) {
+		return io.ReadAll(reader)
+	}
+
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gzip stream %s: %w", path, err)
+	}
+	defer gz.Close()
+
+	return io.ReadAll(gz)
+}
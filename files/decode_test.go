@@ -0,0 +1,62 @@
+package files
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type decodeConfig struct {
+	Name  string   `yaml:"name" json:"name"`
+	Port  int      `yaml:"port" json:"port"`
+	Hosts []string `yaml:"hosts" json:"hosts"`
+}
+
+func writeGzip(t *testing.T, path string, data []byte) {
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	_, err = gz.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, gz.Close())
+}
+
+func TestYAMLFromFileGzip(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte("name: app\nport: 8080\nhosts:\n  - a\n  - b\n")
+	want := &decodeConfig{Name: "app", Port: 8080, Hosts: []string{"a", "b"}}
+
+	plain := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(plain, content, 0644))
+	got, err := YAMLFromFile[decodeConfig](plain)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	compressed := filepath.Join(dir, "config.yaml.gz")
+	writeGzip(t, compressed, content)
+	got, err = YAMLFromFile[decodeConfig](compressed)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	// Detected by the magic header even without the extension.
+	sniffed := filepath.Join(dir, "config.bin")
+	writeGzip(t, sniffed, content)
+	got, err = YAMLFromFile[decodeConfig](sniffed)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestJSONFromFileGzip(t *testing.T) {
+	dir := t.TempDir()
+	compressed := filepath.Join(dir, "config.json.gz")
+	writeGzip(t, compressed, []byte(`{"name":"app","port":8080,"hosts":["a"]}`))
+
+	got, err := JSONFromFile[decodeConfig](compressed)
+	assert.NoError(t, err)
+	assert.Equal(t, &decodeConfig{Name: "app", Port: 8080, Hosts: []string{"a"}}, got)
+}
@@ -0,0 +1,267 @@
+package files
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SymlinkMode controls what CopyDirWithOptions does with symlinks found in the source tree.
+type SymlinkMode int
+
+const (
+	// SymlinkSkip leaves symlinks out of the copy. This is the default.
+	SymlinkSkip SymlinkMode = iota
+	// SymlinkDereference copies the file or directory a symlink points to in place of the link.
+	// A link that points back into a directory being copied is reported as ErrSymlinkCycle.
+	SymlinkDereference
+	// SymlinkPreserve recreates each symlink in the destination with the same target, replacing any
+	// existing file or link at that path.
+	SymlinkPreserve
+)
+
+// CopyDirOptions controls how CopyDirWithOptions copies a tree.
+type CopyDirOptions struct {
+	// Verify re-reads every copied file after the copy and compares its SHA256 digest with the source.
+	Verify bool
+	// Symlinks selects how symlinks in the source are handled. The zero value skips them.
+	Symlinks SymlinkMode
+}
+
+// beforeVerifyHook is called with the destination root between the copy and verification passes.
+// It exists so tests can inject faults.
+var beforeVerifyHook func(dst string)
+
+// CopyDir recursively copies the src directory to dst, preserving file modes.
+// Symlinks are skipped; use CopyDirWithOptions to dereference or preserve them.
+func CopyDir(src, dst string) error {
+	return CopyDirWithOptions(src, dst, CopyDirOptions{})
+}
+
+// CopyDirVerified recursively copies the src directory to dst and then verifies that every
+// destination file has the same SHA256 digest as its source, catching silent corruption during the copy.
+func CopyDirVerified(src, dst string) error {
+	return CopyDirWithOptions(src, dst, CopyDirOptions{Verify: true})
+}
+
+// CopyDirWithOptions recursively copies the src directory to dst according to opts.
+// When opts.Verify is set, the returned error lists every file whose copy does not match the source.
+// Preserved symlinks are not verified.
+func CopyDirWithOptions(src, dst string, opts CopyDirOptions) error {
+	c := &dirCopier{symlinks: opts.Symlinks, active: map[string]bool{}}
+	if err := c.copyDir(src, dst, ""); err != nil {
+		return err
+	}
+
+	if !opts.Verify {
+		return nil
+	}
+
+	if beforeVerifyHook != nil {
+		beforeVerifyHook(dst)
+	}
+
+	var mismatched []string
+	for _, rel := range c.copied {
+		srcSum, err := SHA256(filepath.Join(src, rel))
+		if err != nil {
+			return err
+		}
+		dstSum, err := SHA256(filepath.Join(dst, rel))
+		if err != nil || dstSum != srcSum {
+			mismatched = append(mismatched, rel)
+		}
+	}
+
+	if len(mismatched) > 0 {
+		return fmt.Errorf("copy verification failed for %d files: %s", len(mismatched), strings.Join(mismatched, ", "))
+	}
+
+	return nil
+}
+
+// MoveDir moves the src directory to dst.
+// It uses os.Rename, which is atomic and fast within a filesystem, and only falls back to
+// copying with symlinks preserved followed by os.RemoveAll when the rename fails because
+// src and dst are on different devices.
+func MoveDir(src, dst string) error {
+	err := os.Rename(src, dst)
+	if err == nil || !isCrossDevice(err) {
+		return err
+	}
+
+	if err := CopyDirWithOptions(src, dst, CopyDirOptions{Symlinks: SymlinkPreserve}); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(src)
+}
+
+// DirSize returns the total size in bytes of the regular files in the tree rooted at root.
+// Symlinks are skipped, as in CopyDir, so targets are neither double-counted nor followed into loops.
+func DirSize(root string) (int64, error) {
+	if err := checkDir(root); err != nil {
+		return 0, err
+	}
+
+	var size int64
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+// CountEntries counts the files and directories in root, not including root itself.
+// Only the immediate children are counted unless recurse is set. Anything that is not a directory,
+// including symlinks, counts as a file; symlinked directories are not followed.
+func CountEntries(root string, recurse bool) (files, dirs int, err error) {
+	if err := checkDir(root); err != nil {
+		return 0, 0, err
+	}
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+
+		if !d.IsDir() {
+			files++
+			return nil
+		}
+
+		dirs++
+		if !recurse {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return files, dirs, nil
+}
+
+// checkDir returns an error if root does not exist or is not a directory.
+func checkDir(root string) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		return fmt.Errorf("failed to stat directory %s: %w", root, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", root)
+	}
+	return nil
+}
+
+// dirCopier holds the state shared across the recursive copy of a single tree.
+type dirCopier struct {
+	symlinks SymlinkMode
+	// copied holds the path, relative to the root, of each regular file copied.
+	copied []string
+	// active holds the real paths of the directories currently being copied, to detect cycles when dereferencing.
+	active map[string]bool
+}
+
+// copyDir copies the directory src to dst, recording each copied file relative to the root.
+func (c *dirCopier) copyDir(src, dst, rel string) error {
+	real, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return err
+	}
+	if c.active[real] {
+		return fmt.Errorf("%w: %s resolves to %s", ErrSymlinkCycle, src, real)
+	}
+	c.active[real] = true
+	defer delete(c.active, real)
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dst, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		srcPath := filepath.Join(src, entry.Name())
+		dstPath := filepath.Join(dst, entry.Name())
+		relPath := filepath.Join(rel, entry.Name())
+
+		isDir := entry.IsDir()
+		if entry.Type()&os.ModeSymlink != 0 {
+			switch c.symlinks {
+			case SymlinkPreserve:
+				if err := copySymlink(srcPath, dstPath); err != nil {
+					return err
+				}
+				continue
+			case SymlinkDereference:
+				target, err := os.Stat(srcPath)
+				if err != nil {
+					return err
+				}
+				isDir = target.IsDir()
+			default:
+				continue
+			}
+		}
+
+		if isDir {
+			if err := c.copyDir(srcPath, dstPath, relPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := CopyFile(srcPath, dstPath); err != nil {
+			return err
+		}
+		c.copied = append(c.copied, relPath)
+	}
+
+	return nil
+}
+
+// copySymlink recreates the symlink at src as dst with the same target, removing whatever is at dst first.
+func copySymlink(src, dst string) error {
+	target, err := os.Readlink(src)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Lstat(dst); err == nil {
+		if err := os.Remove(dst); err != nil {
+			return err
+		}
+	}
+
+	return os.Symlink(target, dst)
+}
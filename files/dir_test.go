@@ -0,0 +1,145 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeCopyTree(t *testing.T) string {
+	src := filepath.Join(t.TempDir(), "src")
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "nested"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("alpha"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "nested", "b.txt"), []byte("beta"), 0600))
+	return src
+}
+
+func TestCopyDirVerified(t *testing.T) {
+	src := makeCopyTree(t)
+	dst := filepath.Join(t.TempDir(), "dst")
+
+	assert.NoError(t, CopyDirVerified(src, dst))
+	b, err := os.ReadFile(filepath.Join(dst, "nested", "b.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "beta", string(b))
+}
+
+func TestCopyDirVerifiedDetectsMismatch(t *testing.T) {
+	src := makeCopyTree(t)
+	dst := filepath.Join(t.TempDir(), "dst")
+
+	beforeVerifyHook = func(dst string) {
+		os.WriteFile(filepath.Join(dst, "nested", "b.txt"), []byte("corrupt"), 0600)
+	}
+	defer func() { beforeVerifyHook = nil }()
+
+	err := CopyDirVerified(src, dst)
+	assert.ErrorContains(t, err, filepath.Join("nested", "b.txt"))
+	assert.NotContains(t, err.Error(), "a.txt")
+
+	assert.NoError(t, CopyDirWithOptions(src, dst, CopyDirOptions{Verify: false}))
+}
+
+func TestMoveDir(t *testing.T) {
+	src := makeCopyTree(t)
+	dst := filepath.Join(filepath.Dir(src), "moved")
+
+	before, err := os.Stat(src)
+	assert.NoError(t, err)
+
+	assert.NoError(t, MoveDir(src, dst))
+	assert.False(t, FileExists(src))
+
+	after, err := os.Stat(dst)
+	assert.NoError(t, err)
+	assert.True(t, os.SameFile(before, after), "rename within a filesystem should preserve the inode")
+
+	b, err := os.ReadFile(filepath.Join(dst, "nested", "b.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "beta", string(b))
+}
+
+func TestDirSize(t *testing.T) {
+	src := makeCopyTree(t)
+	if err := os.Symlink(filepath.Join(src, "a.txt"), filepath.Join(src, "link.txt")); err != nil {
+		t.Logf("symlinks not supported: %v", err)
+	}
+
+	size, err := DirSize(src)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len("alpha")+len("beta")), size)
+
+	_, err = DirSize(filepath.Join(src, "missing"))
+	assert.Error(t, err)
+	_, err = DirSize(filepath.Join(src, "a.txt"))
+	assert.ErrorContains(t, err, "not a directory")
+}
+
+func TestCountEntries(t *testing.T) {
+	src := makeCopyTree(t)
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "nested", "deeper"), 0755))
+
+	files, dirs, err := CountEntries(src, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, files)
+	assert.Equal(t, 1, dirs)
+
+	files, dirs, err = CountEntries(src, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, files)
+	assert.Equal(t, 2, dirs)
+
+	_, _, err = CountEntries(filepath.Join(src, "a.txt"), true)
+	assert.ErrorContains(t, err, "not a directory")
+}
+
+func TestCopyDirSymlinks(t *testing.T) {
+	src := makeCopyTree(t)
+	if err := os.Symlink("nested", filepath.Join(src, "latest")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	assert.NoError(t, os.Symlink("a.txt", filepath.Join(src, "alias.txt")))
+
+	skipped := filepath.Join(t.TempDir(), "skip")
+	assert.NoError(t, CopyDir(src, skipped))
+	_, err := os.Lstat(filepath.Join(skipped, "latest"))
+	assert.True(t, os.IsNotExist(err))
+
+	dereferenced := filepath.Join(t.TempDir(), "deref")
+	assert.NoError(t, CopyDirWithOptions(src, dereferenced, CopyDirOptions{Symlinks: SymlinkDereference, Verify: true}))
+	info, err := os.Lstat(filepath.Join(dereferenced, "latest"))
+	assert.NoError(t, err)
+	assert.True(t, info.IsDir())
+	b, err := os.ReadFile(filepath.Join(dereferenced, "latest", "b.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "beta", string(b))
+	info, err = os.Lstat(filepath.Join(dereferenced, "alias.txt"))
+	assert.NoError(t, err)
+	assert.True(t, info.Mode().IsRegular())
+
+	preserved := filepath.Join(t.TempDir(), "preserve")
+	assert.NoError(t, os.MkdirAll(preserved, 0755))
+	assert.NoError(t, os.Symlink("elsewhere", filepath.Join(preserved, "latest")))
+	assert.NoError(t, CopyDirWithOptions(src, preserved, CopyDirOptions{Symlinks: SymlinkPreserve}))
+	target, err := os.Readlink(filepath.Join(preserved, "latest"))
+	assert.NoError(t, err)
+	assert.Equal(t, "nested", target)
+	b, err = os.ReadFile(filepath.Join(preserved, "alias.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "alpha", string(b))
+}
+
+func TestCopyDirDereferenceCycle(t *testing.T) {
+	src := makeCopyTree(t)
+	if err := os.Symlink("..", filepath.Join(src, "nested", "up")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	err := CopyDirWithOptions(src, filepath.Join(t.TempDir(), "dst"), CopyDirOptions{Symlinks: SymlinkDereference})
+	assert.ErrorIs(t, err, ErrSymlinkCycle)
+
+	// Skipping and preserving never follow the link.
+	assert.NoError(t, CopyDirWithOptions(src, filepath.Join(t.TempDir(), "dst"), CopyDirOptions{Symlinks: SymlinkPreserve}))
+}
@@ -0,0 +1,144 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// RunToFile runs the named command and atomically writes its stdout to dst.
+// Stdout is streamed into a temporary file that is renamed over dst only when the command succeeds,
+// so dst is left untouched on a non-zero exit. The returned error wraps the exit error and includes stderr.
+//
+// Arguments:
+//   - ctx: the context used to cancel the command
+//   - dst: the path of the file to write stdout to
+//   - name: the command to run
+//   - args: the arguments to pass to the command
+//
+// Returns:
+//   - an error if the command failed or dst could not be written
+func RunToFile(ctx context.Context, dst string, name string, args ...string) error {
+	return writeAtomicFunc(dst, 0644, func(w io.Writer) error {
+		var stderr bytes.Buffer
+
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stdout = w
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+		}
+
+		return nil
+	})
+}
+
+// RunTee runs the named command, streaming its stdout and stderr to the files at stdoutPath and stderrPath.
+// An empty path means that stream is not written to a file. On failure the returned error wraps the
+// exit error and includes the last 64KiB of the combined output of both streams for diagnostics;
+// only that tail is kept in memory.
+//
+// Arguments:
+//   - ctx: the context used to cancel the command
+//   - stdoutPath: the file to write stdout to, or "" to skip it
+//   - stderrPath: the file to write stderr to, or "" to skip it
+//   - name: the command to run
+//   - args: the arguments to pass to the command
+//
+// Returns:
+//   - an error if the command failed or an output file could not be written
+func RunTee(ctx context.Context, stdoutPath, stderrPath string, name string, args ...string) error {
+	combined := &tailBuffer{max: runTeeTailSize}
+
+	stdout, closeStdout, err := teeTarget(stdoutPath, combined)
+	if err != nil {
+		return err
+	}
+	defer closeStdout()
+
+	stderr, closeStderr, err := teeTarget(stderrPath, combined)
+	if err != nil {
+		return err
+	}
+	defer closeStderr()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(combined.String()))
+	}
+
+	if err := closeStdout(); err != nil {
+		return err
+	}
+
+	return closeStderr()
+}
+
+// teeTarget returns a writer that copies to combined and, when path is not empty, to a newly created file at path.
+// The returned close function is safe to call more than once.
+func teeTarget(path string, combined io.Writer) (io.Writer, func() error, error) {
+	if path == "" {
+		return combined, func() error { return nil }, nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var once sync.Once
+	var closeErr error
+	return io.MultiWriter(file, combined), func() error {
+		once.Do(func() { closeErr = file.Close() })
+		return closeErr
+	}, nil
+}
+
+// runTeeTailSize is how much of the combined output RunTee keeps for its error message.
+const runTeeTailSize = 64 * 1024
+
+// tailBuffer keeps only the last max bytes written to it and is safe for concurrent writes.
+type tailBuffer struct {
+	mu        sync.Mutex
+	max       int
+	buf       []byte
+	truncated bool
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := len(p)
+	if len(p) > b.max {
+		p = p[len(p)-b.max:]
+		b.truncated = true
+	}
+	if overflow := len(b.buf) + len(p) - b.max; overflow > 0 {
+		b.buf = append(b.buf[:0], b.buf[overflow:]...)
+		b.truncated = true
+	}
+	b.buf = append(b.buf, p...)
+
+	return n, nil
+}
+
+// String returns the retained output, prefixed with "..." when earlier output was dropped.
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.truncated {
+		return "..." + string(b.buf)
+	}
+	return string(b.buf)
+}
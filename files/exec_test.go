@@ -0,0 +1,67 @@
+package files
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunToFile(t *testing.T) {
+	dst := filepath.Join(t.TempDir(), "out.txt")
+
+	assert.NoError(t, RunToFile(context.Background(), dst, "echo", "hello", "world"))
+	b, err := os.ReadFile(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world\n", string(b))
+
+	err = RunToFile(context.Background(), dst, "sh", "-c", "echo partial; echo oops >&2; exit 3")
+	var exitErr *exec.ExitError
+	assert.True(t, errors.As(err, &exitErr))
+	assert.ErrorContains(t, err, "oops")
+
+	b, err = os.ReadFile(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world\n", string(b))
+}
+
+func TestRunTee(t *testing.T) {
+	dir := t.TempDir()
+	stdoutPath := filepath.Join(dir, "stdout.log")
+	stderrPath := filepath.Join(dir, "stderr.log")
+
+	err := RunTee(context.Background(), stdoutPath, stderrPath, "sh", "-c", "echo out; echo err >&2")
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(stdoutPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "out\n", string(b))
+	b, err = os.ReadFile(stderrPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "err\n", string(b))
+
+	err = RunTee(context.Background(), "", stderrPath, "sh", "-c", "echo building; echo broken >&2; exit 1")
+	assert.ErrorContains(t, err, "building")
+	assert.ErrorContains(t, err, "broken")
+	b, err = os.ReadFile(stderrPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "broken\n", string(b))
+}
+
+func TestTailBuffer(t *testing.T) {
+	b := &tailBuffer{max: 8}
+	_, _ = b.Write([]byte("abc"))
+	assert.Equal(t, "abc", b.String())
+
+	_, _ = b.Write([]byte("defghij"))
+	assert.Equal(t, "...cdefghij", b.String())
+
+	n, err := b.Write([]byte("0123456789"))
+	assert.NoError(t, err)
+	assert.Equal(t, 10, n)
+	assert.Equal(t, "...23456789", b.String())
+}
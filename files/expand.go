@@ -0,0 +1,90 @@
+package files
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultExpandSeparator separates a variable name from its default value, as in ${VAR:-default}.
+const DefaultExpandSeparator = ":-"
+
+// ExpandOptions controls how environment variables are interpolated by YAMLFromFileExpanded and JSONFromFileExpanded.
+type ExpandOptions struct {
+	// ErrorOnUnset makes an unset variable without a default an error instead of expanding to "".
+	ErrorOnUnset bool
+	// DefaultSeparator separates a variable name from its default value. Defaults to DefaultExpandSeparator.
+	DefaultSeparator string
+}
+
+// YAMLFromFileExpanded is like YAMLFromFile but expands $VAR, ${VAR} and ${VAR:-default} references
+// against the environment before unmarshalling.
+func YAMLFromFileExpanded[T any](path string, opts ...ExpandOptions) (*T, error) {
+	data, err := readExpanded(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeYAML[T](path, data)
+}
+
+// JSONFromFileExpanded is like JSONFromFile but expands $VAR, ${VAR} and ${VAR:-default} references
+// against the environment before unmarshalling.
+func JSONFromFileExpanded[T any](path string, opts ...ExpandOptions) (*T, error) {
+	data, err := readExpanded(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeJSON[T](path, data)
+}
+
+// ExpandEnv replaces $VAR, ${VAR} and ${VAR:-default} references in s with values from the environment.
+// As in the shell, the default is used when the variable is unset or empty, whatever the separator.
+// It returns an error naming the variables that were unset when opts.ErrorOnUnset is set.
+func ExpandEnv(s string, opts ExpandOptions) (string, error) {
+	separator := opts.DefaultSeparator
+	if separator == "" {
+		separator = DefaultExpandSeparator
+	}
+
+	var unset []string
+	expanded := os.Expand(s, func(ref string) string {
+		name, def, hasDefault := strings.Cut(ref, separator)
+		value, ok := os.LookupEnv(name)
+		if hasDefault && value == "" {
+			// As with the shell's ${VAR:-default}, the default also replaces a set but empty value.
+			return def
+		}
+		if !ok {
+			unset = append(unset, name)
+		}
+		return value
+	})
+
+	if opts.ErrorOnUnset && len(unset) > 0 {
+		return "", fmt.Errorf("unset environment variables: %v", unset)
+	}
+
+	return expanded, nil
+}
+
+// readExpanded reads the file at path and expands environment variable references in its content.
+func readExpanded(path string, opts []ExpandOptions) ([]byte, error) {
+	data, err := readMaybeGzip(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var o ExpandOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	expanded, err := ExpandEnv(string(data), o)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand %s: %w", path, err)
+	}
+
+	return []byte(expanded), nil
+}
@@ -0,0 +1,38 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYAMLFromFileExpanded(t *testing.T) {
+	t.Setenv("GO_UTIL_TEST_NAME", "app")
+	t.Setenv("GO_UTIL_TEST_HOST", "db.internal")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "name: ${GO_UTIL_TEST_NAME}\nport: ${GO_UTIL_TEST_PORT:-8080}\nhosts:\n  - $GO_UTIL_TEST_HOST\n  - \"${GO_UTIL_TEST_UNSET}\"\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	got, err := YAMLFromFileExpanded[decodeConfig](path)
+	assert.NoError(t, err)
+	assert.Equal(t, &decodeConfig{Name: "app", Port: 8080, Hosts: []string{"db.internal", ""}}, got)
+
+	_, err = YAMLFromFileExpanded[decodeConfig](path, ExpandOptions{ErrorOnUnset: true})
+	assert.ErrorContains(t, err, "GO_UTIL_TEST_UNSET")
+}
+
+func TestJSONFromFileExpanded(t *testing.T) {
+	t.Setenv("GO_UTIL_TEST_PORT", "9090")
+	t.Setenv("GO_UTIL_TEST_NAME", "")
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	content := `{"name": "${GO_UTIL_TEST_NAME|fallback}", "port": ${GO_UTIL_TEST_PORT|1}}`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	got, err := JSONFromFileExpanded[decodeConfig](path, ExpandOptions{ErrorOnUnset: true, DefaultSeparator: "|"})
+	assert.NoError(t, err)
+	assert.Equal(t, &decodeConfig{Name: "fallback", Port: 9090}, got)
+}
@@ -1,16 +1,15 @@
 package files
 
 import (
-	"bytes"
-	"fmt"
-	"io"
+	"context"
+	"errors"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/mateothegreat/go-multilog/multilog"
+	"github.com/mateothegreat/go-util/paths"
 )
 
 // FileExists checks if the file exists at the given file path.
@@ -42,14 +41,31 @@ func FileExists(filePath string) bool {
 	return false
 }
 
+// ExistsMap checks each of the given paths and reports whether it exists.
+// Unlike filtering down to the existing paths, this keeps track of which paths are missing.
+func ExistsMap(paths []string) map[string]bool {
+	result := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		result[path] = FileExists(path)
+	}
+	return result
+}
+
 // WaitForFileExists waits for the file to exist at the given file path.
 // It returns true if the file exists within the specified timeout, otherwise false.
 // This function periodically checks for the file existence.
 func WaitForFileExists(filePath string, timeout time.Duration) bool {
-	// Create a timer for the timeout
-	timer := time.NewTimer(timeout)
-	defer timer.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
+	exists, _ := WaitForFileExistsContext(ctx, filePath)
+	return exists
+}
+
+// WaitForFileExistsContext waits for the file to exist at the given file path, checking every 100ms.
+// It returns true once the file exists. It returns false with ctx.Err() when ctx is done first,
+// or false with the stat error if checking the file fails for a reason other than it not existing.
+func WaitForFileExistsContext(ctx context.Context, filePath string) (bool, error) {
 	// Create a ticker for periodically checking the file existence
 	checkInterval := 100 * time.Millisecond
 	ticker := time.NewTicker(checkInterval)
@@ -57,84 +73,67 @@ func WaitForFileExists(filePath string, timeout time.Duration) bool {
 
 	for {
 		select {
-		case <-timer.C:
-			// Timeout occurred
-			return false
+		case <-ctx.Done():
+			return false, ctx.Err()
 		case <-ticker.C:
 			if _, err := os.Stat(filePath); err == nil {
 				// File exists
-				return true
+				return true, nil
 			} else if !os.IsNotExist(err) {
 				// An error other than "not exist", stop waiting
-				return false
+				return false, err
 			}
 			// If file does not exist, continue checking
 		}
 	}
 }
 
+// ErrHandleProbeUnavailable is returned by WaitForNoFileHandlers when open handles cannot be checked at all,
+// for example because `lsof` is not installed.
+var ErrHandleProbeUnavailable = errors.New("file handle probe unavailable")
+
 // WaitForNoFileHandlers waits for all file handlers to be closed for the given file path.
 // It returns true if all file handlers are closed within the specified timeout, otherwise false.
-// This function uses the `lsof` command to check for open file handlers.
-func WaitForNoFileHandlers(filePath string, timeout time.Duration, local bool) bool {
+//
+// On unix this uses `lsof -F n` and compares the reported names exactly against the path. When local is true
+// lsof is asked about the path directly, which is fast; when local is false every open file on the system is
+// listed and filtered, which also finds handles lsof cannot attribute to the path directly (such as on some
+// network mounts). On Windows local is ignored and the file is probed by opening it without sharing.
+//
+// An error wrapping ErrHandleProbeUnavailable is returned when the check cannot be performed, so callers can
+// distinguish "handles still open" from "couldn't check".
+func WaitForNoFileHandlers(filePath string, timeout time.Duration, local bool) (bool, error) {
 	deadline := time.Now().Add(timeout)
 	for time.Now().Before(deadline) {
-		var cmd *exec.Cmd
-		if local {
-			cmd = exec.Command("lsof", filePath)
-		} else {
-			cmd = exec.Command("sh", "-c", fmt.Sprintf("lsof | grep %s", filePath))
-		}
-
-		var out bytes.Buffer
-		cmd.Stdout = &out
-
-		err := cmd.Run()
+		open, err := hasOpenHandles(filePath, local)
 		if err != nil {
-			// lsof returns an error if no file handlers are found.
-			return true
+			return false, err
 		}
 
-		if out.Len() == 0 {
-			// Also check if the output is empty, indicating no open file handlers.
-			return true
+		if !open {
+			return true, nil
 		}
 
 		time.Sleep(100 * time.Millisecond) // Wait before trying again.
 	}
 
-	return false // Timeout reached.
+	return false, nil // Timeout reached.
 }
 
+// MoveFile moves the src file to dst.
+// It uses os.Rename, which is atomic and fast within a filesystem, and only falls back to
+// CopyFile followed by os.Remove when the rename fails because src and dst are on different devices.
 func MoveFile(src, dst string) error {
-	sourceFile, err := os.Open(src)
-	if err != nil {
-		return err
-	}
-	defer sourceFile.Close()
-
-	destinationFile, err := os.Create(dst)
-	if err != nil {
-		return err
-	}
-	defer destinationFile.Close()
-
-	_, err = io.Copy(destinationFile, sourceFile)
-	if err != nil {
-		return err
-	}
-
-	err = sourceFile.Close()
-	if err != nil {
+	err := os.Rename(src, dst)
+	if err == nil || !isCrossDevice(err) {
 		return err
 	}
 
-	err = os.Remove(src)
-	if err != nil {
+	if err := CopyFile(src, dst); err != nil {
 		return err
 	}
 
-	return nil
+	return os.Remove(src)
 }
 
 // WalkFile walks up the directory tree from the current directory to find the given file.
@@ -189,10 +188,7 @@ func ExpandPath(path string) string {
 	return abs
 }
 
+// IsSubPath reports whether path is basePath or lies beneath it. See paths.IsSubPath.
 func IsSubPath(path, basePath string) bool {
-	rel, err := filepath.Rel(basePath, path)
-	if err != nil {
-		return false
-	}
-	return !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+	return paths.IsSubPath(path, basePath)
 }
@@ -1,7 +1,13 @@
 package files
 
 import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -11,3 +17,117 @@ func TestExpandPath(t *testing.T) {
 	expanded := ExpandPath(path)
 	assert.Equal(t, "/Users/matthewdavis/test", expanded)
 }
+
+func TestExistsMap(t *testing.T) {
+	dir := t.TempDir()
+	present := filepath.Join(dir, "present.txt")
+	missing := filepath.Join(dir, "missing.txt")
+	assert.NoError(t, os.WriteFile(present, []byte("x"), 0644))
+
+	assert.Equal(t, map[string]bool{
+		present: true,
+		missing: false,
+		dir:     true,
+	}, ExistsMap([]string{present, missing, dir}))
+}
+
+func TestWaitForNoFileHandlers(t *testing.T) {
+	if _, err := exec.LookPath("lsof"); err != nil && runtime.GOOS != "windows" {
+		t.Skip("lsof is not installed")
+	}
+
+	path := filepath.Join(t.TempDir(), "busy.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("x"), 0644))
+
+	closed, err := WaitForNoFileHandlers(path, time.Second, true)
+	assert.NoError(t, err)
+	assert.True(t, closed)
+
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	if runtime.GOOS != "windows" {
+		closed, err = WaitForNoFileHandlers(path, 300*time.Millisecond, true)
+		assert.NoError(t, err)
+		assert.False(t, closed)
+
+		// A sibling whose name contains the busy path as a prefix must not count as open.
+		sibling := path + ".bak"
+		assert.NoError(t, os.WriteFile(sibling, []byte("x"), 0644))
+		closed, err = WaitForNoFileHandlers(sibling, time.Second, true)
+		assert.NoError(t, err)
+		assert.True(t, closed)
+	}
+}
+
+func TestMoveFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+	assert.NoError(t, os.WriteFile(src, []byte("hello"), 0600))
+
+	assert.NoError(t, MoveFile(src, dst))
+	assert.NoFileExists(t, src)
+
+	b, err := os.ReadFile(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(dst)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	assert.Error(t, MoveFile(src, dst))
+}
+
+func TestWaitForFileExistsContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ready")
+
+	go func() {
+		time.Sleep(150 * time.Millisecond)
+		_ = os.WriteFile(path, nil, 0644)
+	}()
+
+	exists, err := WaitForFileExistsContext(context.Background(), path)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	exists, err = WaitForFileExistsContext(ctx, filepath.Join(t.TempDir(), "never"))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, exists)
+
+	assert.False(t, WaitForFileExists(filepath.Join(t.TempDir(), "never"), 150*time.Millisecond))
+
+	if runtime.GOOS != "windows" {
+		// A path below a regular file fails with ENOTDIR, which must be reported rather than waited on.
+		_, err = WaitForFileExistsContext(context.Background(), filepath.Join(path, "child"))
+		assert.Error(t, err)
+	}
+}
+
+func TestWaitForNoFileHandlersSymlinkedDir(t *testing.T) {
+	if _, err := exec.LookPath("lsof"); err != nil || runtime.GOOS == "windows" {
+		t.Skip("lsof is not installed")
+	}
+
+	dir := t.TempDir()
+	real := filepath.Join(dir, "real")
+	assert.NoError(t, os.MkdirAll(real, 0755))
+	if err := os.Symlink(real, filepath.Join(dir, "link")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	assert.NoError(t, os.WriteFile(filepath.Join(real, "busy.txt"), []byte("x"), 0644))
+
+	file, err := os.Open(filepath.Join(real, "busy.txt"))
+	assert.NoError(t, err)
+	defer file.Close()
+
+	closed, err := WaitForNoFileHandlers(filepath.Join(dir, "link", "busy.txt"), 300*time.Millisecond, true)
+	assert.NoError(t, err)
+	assert.False(t, closed)
+}
@@ -0,0 +1,53 @@
+package files
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FindLatest returns the path of the most recently modified file in dir matching the glob pattern.
+// Ties are broken by choosing the lexically smallest path so the result is deterministic.
+// An error is returned if no file matches.
+func FindLatest(dir, pattern string) (string, error) {
+	return findByModTime(dir, pattern, func(a, b time.Time) bool { return a.After(b) })
+}
+
+// FindOldest returns the path of the least recently modified file in dir matching the glob pattern.
+// Ties are broken by choosing the lexically smallest path so the result is deterministic.
+// An error is returned if no file matches.
+func FindOldest(dir, pattern string) (string, error) {
+	return findByModTime(dir, pattern, func(a, b time.Time) bool { return a.Before(b) })
+}
+
+// findByModTime returns the matching file whose modification time is preferred by better.
+func findByModTime(dir, pattern string, better func(a, b time.Time) bool) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return "", err
+	}
+
+	var best string
+	var bestTime time.Time
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			return "", err
+		}
+		if info.IsDir() {
+			continue
+		}
+
+		modTime := info.ModTime()
+		if best == "" || better(modTime, bestTime) || (modTime.Equal(bestTime) && match < best) {
+			best, bestTime = match, modTime
+		}
+	}
+
+	if best == "" {
+		return "", fmt.Errorf("no files matching %s in %s", pattern, dir)
+	}
+
+	return best, nil
+}
@@ -0,0 +1,38 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindLatestAndOldest(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now().Truncate(time.Second)
+	files := map[string]time.Time{
+		"backup-a.tar": now.Add(-3 * time.Hour),
+		"backup-b.tar": now.Add(-1 * time.Hour),
+		"backup-c.tar": now.Add(-1 * time.Hour),
+		"backup-d.tar": now.Add(-2 * time.Hour),
+		"newest.log":   now,
+	}
+	for name, modTime := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(name), 0644))
+		assert.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	latest, err := FindLatest(dir, "backup-*.tar")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "backup-b.tar"), latest)
+
+	oldest, err := FindOldest(dir, "backup-*.tar")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "backup-a.tar"), oldest)
+
+	_, err = FindLatest(dir, "*.zip")
+	assert.Error(t, err)
+}
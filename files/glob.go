@@ -0,0 +1,129 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrSymlinkCycle is returned when a walk reaches a directory that is already being walked through a symlink.
+var ErrSymlinkCycle = errors.New("symlink cycle detected")
+
+// GlobAllFiles recursively walks basedir and calls fn for every file whose base name matches pattern.
+// The pattern syntax is that of filepath.Match. Walking stops at the first error returned by fn.
+func GlobAllFiles(basedir, pattern string, fn func(string) error) error {
+	return GlobAllFilesFiltered(basedir, pattern, nil, fn)
+}
+
+// GlobAllFilesFiltered is like GlobAllFiles but skips every file and directory for which IgnoreFile reports
+// a match against ignores, so trees such as node_modules or .git are never descended into.
+// Symlinked directories are followed and dangling symlinks are skipped. A symlink cycle ends the walk
+// with an error wrapping ErrSymlinkCycle.
+func GlobAllFilesFiltered(basedir, pattern string, ignores []string, fn func(string) error) error {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return err
+	}
+
+	return newGlobWalker(basedir, ignores, func(name string) bool {
+		ok, _ := filepath.Match(pattern, name)
+		return ok
+	}, fn).walk(basedir)
+}
+
+// GlobMulti recursively walks basedir and calls fn for every file whose base name matches any of patterns.
+// A file matched by more than one pattern is only passed to fn once.
+func GlobMulti(basedir string, patterns []string, fn func(string) error) error {
+	for _, pattern := range patterns {
+		// Validate patterns up front so a bad pattern is reported even if nothing is walked.
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return err
+		}
+	}
+
+	return newGlobWalker(basedir, nil, func(name string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := filepath.Match(pattern, name); ok {
+				return true
+			}
+		}
+		return false
+	}, fn).walk(basedir)
+}
+
+// IgnoreFile reports whether path, relative to the walked root, should be ignored.
+// A pattern matches when it matches either the whole slash-separated path or its base name,
+// so "node_modules" ignores the directory at any depth while "build/*.tmp" only matches within build.
+func IgnoreFile(path string, ignores []string) bool {
+	return matchesAnyGlob(ignores, filepath.ToSlash(path))
+}
+
+// globWalker holds the state shared across the recursive walk of a single glob.
+type globWalker struct {
+	root    string
+	ignores []string
+	match   func(name string) bool
+	fn      func(string) error
+	// active holds the real paths of the directories currently being walked, to detect cycles.
+	active map[string]bool
+}
+
+func newGlobWalker(root string, ignores []string, match func(name string) bool, fn func(string) error) *globWalker {
+	return &globWalker{root: root, ignores: ignores, match: match, fn: fn, active: map[string]bool{}}
+}
+
+// walk calls fn for each file under dir whose base name satisfies match, descending into subdirectories.
+func (g *globWalker) walk(dir string) error {
+	real, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	if g.active[real] {
+		return fmt.Errorf("%w: %s resolves to %s", ErrSymlinkCycle, dir, real)
+	}
+	g.active[real] = true
+	defer delete(g.active, real)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		if len(g.ignores) > 0 {
+			rel, err := filepath.Rel(g.root, path)
+			if err != nil {
+				return err
+			}
+			if IgnoreFile(rel, g.ignores) {
+				continue
+			}
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			if entry.Type()&os.ModeSymlink != 0 && os.IsNotExist(err) {
+				// A dangling symlink has nothing to match or descend into.
+				continue
+			}
+			return err
+		}
+
+		if info.IsDir() {
+			if err := g.walk(path); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if g.match(entry.Name()) {
+			if err := g.fn(path); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
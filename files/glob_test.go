@@ -0,0 +1,98 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeGlobTree(t *testing.T) string {
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "cmd", "app"), 0755))
+	for _, name := range []string{"go.mod", "main.go", "README.md", filepath.Join("cmd", "app", "app.go"), filepath.Join("cmd", "app", "app_test.go")} {
+		assert.NoError(t, os.WriteFile(filepath.Join(root, name), nil, 0644))
+	}
+	return root
+}
+
+func TestGlobAllFiles(t *testing.T) {
+	root := makeGlobTree(t)
+
+	var got []string
+	assert.NoError(t, GlobAllFiles(root, "*.go", func(path string) error {
+		got = append(got, path)
+		return nil
+	}))
+	assert.ElementsMatch(t, []string{
+		filepath.Join(root, "main.go"),
+		filepath.Join(root, "cmd", "app", "app.go"),
+		filepath.Join(root, "cmd", "app", "app_test.go"),
+	}, got)
+}
+
+func TestGlobMulti(t *testing.T) {
+	root := makeGlobTree(t)
+
+	calls := map[string]int{}
+	assert.NoError(t, GlobMulti(root, []string{"*.go", "*.mod", "app*"}, func(path string) error {
+		calls[path]++
+		return nil
+	}))
+	assert.Equal(t, map[string]int{
+		filepath.Join(root, "go.mod"):                    1,
+		filepath.Join(root, "main.go"):                   1,
+		filepath.Join(root, "cmd", "app", "app.go"):      1,
+		filepath.Join(root, "cmd", "app", "app_test.go"): 1,
+	}, calls)
+
+	assert.Error(t, GlobMulti(root, []string{"["}, func(string) error { return nil }))
+}
+
+func TestGlobAllFilesFiltered(t *testing.T) {
+	root := makeGlobTree(t)
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "node_modules", "dep"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "node_modules", "dep", "dep.go"), nil, 0644))
+
+	var got []string
+	assert.NoError(t, GlobAllFilesFiltered(root, "*.go", []string{"node_modules", "cmd/app/*_test.go"}, func(path string) error {
+		got = append(got, path)
+		return nil
+	}))
+	assert.ElementsMatch(t, []string{
+		filepath.Join(root, "main.go"),
+		filepath.Join(root, "cmd", "app", "app.go"),
+	}, got)
+}
+
+func TestGlobAllFilesSymlinkCycle(t *testing.T) {
+	root := makeGlobTree(t)
+	if err := os.Symlink(root, filepath.Join(root, "cmd", "loop")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	err := GlobAllFiles(root, "*.go", func(string) error { return nil })
+	assert.ErrorIs(t, err, ErrSymlinkCycle)
+
+	// Ignoring the link avoids the cycle altogether.
+	assert.NoError(t, GlobAllFilesFiltered(root, "*.go", []string{"loop"}, func(string) error { return nil }))
+}
+
+func TestGlobAllFilesDanglingSymlink(t *testing.T) {
+	root := makeGlobTree(t)
+	if err := os.Symlink(filepath.Join(root, "missing.go"), filepath.Join(root, "cmd", "broken.go")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	var got []string
+	assert.NoError(t, GlobAllFiles(root, "*.go", func(path string) error {
+		got = append(got, path)
+		return nil
+	}))
+	assert.ElementsMatch(t, []string{
+		filepath.Join(root, "main.go"),
+		filepath.Join(root, "cmd", "app", "app.go"),
+		filepath.Join(root, "cmd", "app", "app_test.go"),
+	}, got)
+}
@@ -0,0 +1,74 @@
+//go:build !windows
+
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// hasOpenHandles reports whether any process holds filePath open, according to lsof's structured output.
+func hasOpenHandles(filePath string, local bool) (bool, error) {
+	lsof, err := exec.LookPath("lsof")
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrHandleProbeUnavailable, err)
+	}
+
+	abs, err := resolvedPath(filePath)
+	if err != nil {
+		return false, err
+	}
+
+	args := []string{"-F", "n"}
+	if local {
+		args = append(args, "--", abs)
+	}
+
+	var out bytes.Buffer
+	cmd := exec.Command(lsof, args...)
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return false, fmt.Errorf("%w: %v", ErrHandleProbeUnavailable, err)
+		}
+		// lsof exits non-zero when it finds no open files; any names it did print are still checked below.
+	}
+
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		// Each field is on its own line, prefixed by its identifier; "n" holds the file name.
+		if name, ok := strings.CutPrefix(scanner.Text(), "n"); ok && name == abs {
+			return true, nil
+		}
+	}
+
+	return false, scanner.Err()
+}
+
+// resolvedPath returns the absolute path of filePath with symlinks resolved, since lsof reports the path
+// the kernel resolved (e.g. /private/var rather than /var on macOS). A missing file falls back to the
+// absolute path, as it cannot be held open anyway.
+func resolvedPath(filePath string) (string, error) {
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return abs, nil
+		}
+		return "", err
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,41 @@
+//go:build windows
+
+package files
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"syscall"
+)
+
+// errorSharingViolation is returned by CreateFile when another handle prevents the requested sharing mode.
+const errorSharingViolation syscall.Errno = 32
+
+// hasOpenHandles reports whether filePath is held open by another handle by attempting to open it
+// without sharing. The local flag has no meaning on Windows and is ignored.
+func hasOpenHandles(filePath string, local bool) (bool, error) {
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		return false, err
+	}
+
+	p, err := syscall.UTF16PtrFromString(abs)
+	if err != nil {
+		return false, err
+	}
+
+	handle, err := syscall.CreateFile(p, syscall.GENERIC_READ, 0, nil, syscall.OPEN_EXISTING, syscall.FILE_ATTRIBUTE_NORMAL, 0)
+	if err != nil {
+		switch {
+		case errors.Is(err, errorSharingViolation):
+			return true, nil
+		case errors.Is(err, syscall.ERROR_FILE_NOT_FOUND), errors.Is(err, syscall.ERROR_PATH_NOT_FOUND):
+			// A file that doesn't exist can't be held open.
+			return false, nil
+		}
+		return false, fmt.Errorf("%w: %v", ErrHandleProbeUnavailable, err)
+	}
+
+	return false, syscall.CloseHandle(handle)
+}
@@ -0,0 +1,87 @@
+package files
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// MergeJSON deep-merges the override document onto the base document.
+// Objects are merged recursively; arrays and scalars in override replace the
+// value in base entirely (arrays are never concatenated or merged element-wise).
+//
+// Arguments:
+//   - base: the base JSON document
+//   - override: the JSON document whose values take precedence
+//
+// Returns:
+//   - the merged JSON document
+//   - an error if either document is not valid JSON
+func MergeJSON(base, override []byte) ([]byte, error) {
+	baseValue, err := decodeJSONValue(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base json: %w", err)
+	}
+
+	overrideValue, err := decodeJSONValue(override)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode override json: %w", err)
+	}
+
+	return json.MarshalIndent(mergeJSONValues(baseValue, overrideValue), "", "  ")
+}
+
+// MergeJSONFiles deep-merges the override file onto the base file and atomically writes the result to out.
+// See MergeJSON for the merge semantics.
+func MergeJSONFiles(base, override, out string) error {
+	baseBytes, err := os.ReadFile(base)
+	if err != nil {
+		return err
+	}
+
+	overrideBytes, err := os.ReadFile(override)
+	if err != nil {
+		return err
+	}
+
+	merged, err := MergeJSON(baseBytes, overrideBytes)
+	if err != nil {
+		return err
+	}
+
+	return writeAtomicFunc(out, 0644, func(w io.Writer) error {
+		_, err := w.Write(append(merged, '\n'))
+		return err
+	})
+}
+
+// decodeJSONValue decodes data preserving numbers as json.Number so they round-trip unchanged.
+func decodeJSONValue(data []byte) (interface{}, error) {
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func mergeJSONValues(base, override interface{}) interface{} {
+	baseMap, baseOk := base.(map[string]interface{})
+	overrideMap, overrideOk := override.(map[string]interface{})
+	if !baseOk || !overrideOk {
+		return override
+	}
+
+	for key, value := range overrideMap {
+		if existing, ok := baseMap[key]; ok {
+			baseMap[key] = mergeJSONValues(existing, value)
+		} else {
+			baseMap[key] = value
+		}
+	}
+
+	return baseMap
+}
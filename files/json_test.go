@@ -0,0 +1,40 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeJSON(t *testing.T) {
+	base := []byte(`{"name":"app","server":{"host":"localhost","port":80,"tls":{"enabled":false}},"tags":["a","b"]}`)
+	override := []byte(`{"server":{"port":443,"tls":{"enabled":true,"cert":"x.pem"}},"tags":["c"],"debug":true}`)
+
+	merged, err := MergeJSON(base, override)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "app",
+		"server": {"host": "localhost", "port": 443, "tls": {"enabled": true, "cert": "x.pem"}},
+		"tags": ["c"],
+		"debug": true
+	}`, string(merged))
+
+	_, err = MergeJSON([]byte(`{`), override)
+	assert.Error(t, err)
+}
+
+func TestMergeJSONFiles(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.json")
+	override := filepath.Join(dir, "override.json")
+	out := filepath.Join(dir, "out.json")
+	assert.NoError(t, os.WriteFile(base, []byte(`{"a":{"b":1,"c":2}}`), 0644))
+	assert.NoError(t, os.WriteFile(override, []byte(`{"a":{"c":3,"d":4}}`), 0644))
+
+	assert.NoError(t, MergeJSONFiles(base, override, out))
+	b, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"a":{"b":1,"c":3,"d":4}}`, string(b))
+}
@@ -0,0 +1,203 @@
+package files
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TransformLines streams src line by line through fn and atomically writes the result to dst.
+// fn returns the (possibly modified) line and whether to keep it; dropped lines are omitted from dst.
+// Both files are streamed so memory use stays constant regardless of file size.
+//
+// Arguments:
+//   - src: the path of the file to read
+//   - dst: the path of the file to write
+//   - fn: the transform applied to each line (without its trailing newline)
+//
+// Returns:
+//   - an error if reading src or writing dst failed
+func TransformLines(src, dst string, fn func(line string) (string, bool)) error {
+	sourceFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+
+	stat, err := sourceFile.Stat()
+	if err != nil {
+		return err
+	}
+
+	return writeAtomicFunc(dst, stat.Mode().Perm(), func(w io.Writer) error {
+		reader := bufio.NewReader(sourceFile)
+		for {
+			line, err := reader.ReadString('\n')
+			if len(line) > 0 {
+				content, hasNewline := strings.CutSuffix(line, "\n")
+				if out, keep := fn(content); keep {
+					// Keep each line's own ending so a source without a trailing newline doesn't gain one.
+					if hasNewline {
+						out += "\n"
+					}
+					if _, err := io.WriteString(w, out); err != nil {
+						return err
+					}
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// Grep streams the file at path and calls fn with the 1-based line number and content
+// (without its trailing newline) of every line matching re. It stops at the first error returned by fn.
+func Grep(path string, re *regexp.Regexp, fn func(lineNum int, line string) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for lineNum := 1; ; lineNum++ {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			line = strings.TrimSuffix(line, "\n")
+			if re.MatchString(line) {
+				if err := fn(lineNum, line); err != nil {
+					return err
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// GrepCount returns the number of lines in the file at path matching re.
+func GrepCount(path string, re *regexp.Regexp) (int, error) {
+	count := 0
+	err := Grep(path, re, func(int, string) error {
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// SplitByLines splits src into parts files in dstDir, each holding a contiguous block of roughly the same
+// number of lines. Lines are never split across files and the last part absorbs any remainder, so
+// concatenating the parts in order reproduces src exactly. Both passes over src are streamed.
+//
+// Arguments:
+//   - src: the file to split
+//   - dstDir: the existing directory to write the parts to, named "<base>.part<N>"
+//   - parts: the number of parts to produce
+//
+// Returns:
+//   - the paths of the parts in order
+//   - an error if src could not be read or a part could not be written
+func SplitByLines(src, dstDir string, parts int) ([]string, error) {
+	if parts <= 0 {
+		return nil, fmt.Errorf("invalid number of parts %d", parts)
+	}
+
+	total, err := countLines(src)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceFile, err := os.Open(src)
+	if err != nil {
+		return nil, err
+	}
+	defer sourceFile.Close()
+
+	reader := bufio.NewReader(sourceFile)
+	perPart := total / parts
+	width := len(strconv.Itoa(parts))
+
+	paths := make([]string, 0, parts)
+	for i := 0; i < parts; i++ {
+		lines := perPart
+		if i == parts-1 {
+			lines = -1
+		}
+
+		path := filepath.Join(dstDir, fmt.Sprintf("%s.part%0*d", filepath.Base(src), width, i+1))
+		if err := writeLines(path, reader, lines); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// countLines returns the number of lines in the file at path, counting a final line without a newline.
+func countLines(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	count := 0
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			count++
+		}
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// writeLines copies up to n lines from reader into a new file at path, or every remaining line when n is negative.
+func writeLines(path string, reader *bufio.Reader, n int) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	for i := 0; n < 0 || i < n; i++ {
+		line, err := reader.ReadString('\n')
+		if _, werr := w.WriteString(line); werr != nil {
+			return werr
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
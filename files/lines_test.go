@@ -0,0 +1,99 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformLines(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "app.log")
+	dst := filepath.Join(dir, "scrubbed.log")
+	assert.NoError(t, os.WriteFile(src, []byte("user=alice password=hunter2\nDEBUG noisy\nuser=bob ok\n"), 0644))
+
+	err := TransformLines(src, dst, func(line string) (string, bool) {
+		if strings.HasPrefix(line, "DEBUG") {
+			return "", false
+		}
+		return strings.ReplaceAll(line, "hunter2", "[REDACTED]"), true
+	})
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, "user=alice password=[REDACTED]\nuser=bob ok\n", string(b))
+
+	assert.NoError(t, os.WriteFile(src, []byte("a\nb"), 0644))
+	assert.NoError(t, TransformLines(src, dst, func(line string) (string, bool) {
+		return strings.ToUpper(line), true
+	}))
+	b, err = os.ReadFile(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, "A\nB", string(b), "no trailing newline is added")
+}
+
+func TestGrep(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	assert.NoError(t, os.WriteFile(path, []byte("INFO start\nERROR disk full\nINFO retry\nERROR timeout"), 0644))
+	re := regexp.MustCompile(`^ERROR`)
+
+	var lineNums []int
+	var lines []string
+	err := Grep(path, re, func(lineNum int, line string) error {
+		lineNums = append(lineNums, lineNum)
+		lines = append(lines, line)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 4}, lineNums)
+	assert.Equal(t, []string{"ERROR disk full", "ERROR timeout"}, lines)
+
+	count, err := GrepCount(path, regexp.MustCompile(`INFO|ERROR`))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = Grep(path, re, func(int, string) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestSplitByLines(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "data.csv")
+	var content strings.Builder
+	for i := 1; i <= 10; i++ {
+		fmt.Fprintf(&content, "row %d\n", i)
+	}
+	content.WriteString("trailing row without newline")
+	assert.NoError(t, os.WriteFile(src, []byte(content.String()), 0644))
+
+	out := filepath.Join(dir, "parts")
+	assert.NoError(t, os.Mkdir(out, 0755))
+
+	parts, err := SplitByLines(src, out, 3)
+	assert.NoError(t, err)
+	assert.Len(t, parts, 3)
+
+	var joined strings.Builder
+	var counts []int
+	for _, part := range parts {
+		b, err := os.ReadFile(part)
+		assert.NoError(t, err)
+		joined.Write(b)
+		counts = append(counts, strings.Count(string(b), "row"))
+	}
+	assert.Equal(t, content.String(), joined.String())
+	assert.Equal(t, []int{3, 3, 5}, counts)
+}
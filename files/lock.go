@@ -0,0 +1,37 @@
+package files
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+// FileMutex is a registry of per-path mutexes used to serialize access to files within a process.
+// Paths are keyed by their absolute form so different spellings of the same path share a lock.
+// The zero value is ready to use.
+type FileMutex struct {
+	locks sync.Map
+}
+
+// WithFileLock holds the mutex for path for the duration of fn and returns fn's error.
+func (m *FileMutex) WithFileLock(path string, fn func() error) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	lock, _ := m.locks.LoadOrStore(abs, &sync.Mutex{})
+	mu := lock.(*sync.Mutex)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	return fn()
+}
+
+var fileMutex FileMutex
+
+// WithFileLock holds a process-wide mutex for path for the duration of fn and returns fn's error.
+// This prevents interleaved writes from multiple goroutines; it does not lock against other processes.
+func WithFileLock(path string, fn func() error) error {
+	return fileMutex.WithFileLock(path, fn)
+}
@@ -0,0 +1,43 @@
+package files
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithFileLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shared.txt")
+	assert.NoError(t, os.WriteFile(path, nil, 0644))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := WithFileLock(path, func() error {
+				// Read-modify-write would lose lines if writers interleaved.
+				b, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				return os.WriteFile(path, append(b, []byte(fmt.Sprintf("writer %d\n", i))...), 0644)
+			})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	assert.Len(t, lines, 20)
+	for i := 0; i < 20; i++ {
+		assert.Contains(t, lines, fmt.Sprintf("writer %d", i))
+	}
+}
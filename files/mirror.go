@@ -0,0 +1,171 @@
+package files
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// MirrorOptions controls how Mirror brings dst in line with src.
+type MirrorOptions struct {
+	// DeleteExtra removes files and directories in dst that do not exist in src.
+	DeleteExtra bool
+	// DryRun computes the report without touching the filesystem.
+	DryRun bool
+}
+
+// MirrorReport lists the paths, relative to the mirrored roots and using forward slashes, that Mirror acted on.
+type MirrorReport struct {
+	// Created holds files and directories that were missing from dst.
+	Created []string
+	// Updated holds files whose size or modification time differed from src, or whose type changed.
+	Updated []string
+	// Deleted holds extra entries removed from dst. A deleted directory is listed once, not per child.
+	Deleted []string
+	// Skipped holds files that were already up to date.
+	Skipped []string
+}
+
+// Mirror makes dst a copy of the src directory and reports every action it took.
+// Files are compared by size and modification time; CopyFile preserves the modification time,
+// so unchanged files are skipped on subsequent runs. Symlinks in src are ignored, as with CopyDir.
+//
+// Arguments:
+//   - src: the directory to mirror from
+//   - dst: the directory to mirror into (created if missing)
+//   - opts: whether to delete extra entries in dst and whether to only report what would change
+//
+// Returns:
+//   - a report of created, updated, deleted and skipped paths
+//   - an error if a walk or copy failed; the report contains the actions taken up to that point
+func Mirror(src, dst string, opts MirrorOptions) (MirrorReport, error) {
+	var report MirrorReport
+
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		dstInfo, err := os.Lstat(target)
+		if err != nil && !isMissing(err) {
+			return err
+		}
+		exists := err == nil
+
+		if d.IsDir() {
+			return mirrorDir(path, target, rel, dstInfo, exists, opts, &report)
+		}
+
+		srcInfo, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case !exists:
+			report.Created = append(report.Created, filepath.ToSlash(rel))
+		case dstInfo.Mode().IsRegular() && dstInfo.Size() == srcInfo.Size() && dstInfo.ModTime().Equal(srcInfo.ModTime()):
+			report.Skipped = append(report.Skipped, filepath.ToSlash(rel))
+			return nil
+		default:
+			report.Updated = append(report.Updated, filepath.ToSlash(rel))
+			if !dstInfo.Mode().IsRegular() && !opts.DryRun {
+				if err := os.RemoveAll(target); err != nil {
+					return err
+				}
+			}
+		}
+
+		if opts.DryRun {
+			return nil
+		}
+		return CopyFile(path, target)
+	})
+	if err != nil || !opts.DeleteExtra {
+		return report, err
+	}
+
+	err = filepath.WalkDir(dst, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Entries may disappear underneath the walk once their parent is removed.
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+
+		rel, err := filepath.Rel(dst, path)
+		if err != nil || rel == "." {
+			return err
+		}
+
+		srcInfo, err := os.Lstat(filepath.Join(src, rel))
+		if err != nil && !isMissing(err) {
+			return err
+		}
+		if err == nil && srcInfo.Mode()&fs.ModeSymlink == 0 {
+			if d.IsDir() && !srcInfo.IsDir() {
+				// The directory is replaced by a file from src and was reported as updated.
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		report.Deleted = append(report.Deleted, filepath.ToSlash(rel))
+		if !opts.DryRun {
+			if err := os.RemoveAll(path); err != nil {
+				return err
+			}
+		}
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+
+	return report, err
+}
+
+// mirrorDir ensures the directory target exists for the source directory at path, recording it as created
+// when it was missing or replaced a non-directory.
+func mirrorDir(path, target, rel string, dstInfo fs.FileInfo, exists bool, opts MirrorOptions, report *MirrorReport) error {
+	if exists && dstInfo.IsDir() {
+		return nil
+	}
+
+	if rel != "." {
+		report.Created = append(report.Created, filepath.ToSlash(rel))
+	}
+	if opts.DryRun {
+		return nil
+	}
+
+	if exists {
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(target, info.Mode().Perm())
+}
+
+// isMissing reports whether err means a path does not exist, including when a parent component is
+// a file (ENOTDIR), as happens in a dry run where dst still has a file in place of a source directory.
+func isMissing(err error) bool {
+	return os.IsNotExist(err) || errors.Is(err, syscall.ENOTDIR)
+}
@@ -0,0 +1,112 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMirror(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "src")
+	dst := filepath.Join(t.TempDir(), "dst")
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("alpha"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "sub", "b.txt"), []byte("beta"), 0644))
+
+	// Dry run against an empty destination reports everything as created and writes nothing.
+	report, err := Mirror(src, dst, MirrorOptions{DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "sub", "sub/b.txt"}, report.Created)
+	assert.NoDirExists(t, dst)
+
+	report, err = Mirror(src, dst, MirrorOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "sub", "sub/b.txt"}, report.Created)
+	b, err := os.ReadFile(filepath.Join(dst, "sub", "b.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "beta", string(b))
+
+	// Update one file, add an extra file and directory to dst.
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("alpha v2"), 0644))
+	later := time.Now().Add(time.Hour)
+	assert.NoError(t, os.Chtimes(filepath.Join(src, "a.txt"), later, later))
+	assert.NoError(t, os.WriteFile(filepath.Join(dst, "extra.txt"), []byte("extra"), 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dst, "old", "nested"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dst, "old", "nested", "c.txt"), []byte("c"), 0644))
+
+	want := MirrorReport{
+		Updated: []string{"a.txt"},
+		Deleted: []string{"extra.txt", "old"},
+		Skipped: []string{"sub/b.txt"},
+	}
+
+	report, err = Mirror(src, dst, MirrorOptions{DeleteExtra: true, DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, want, report)
+	assert.FileExists(t, filepath.Join(dst, "extra.txt"))
+	b, err = os.ReadFile(filepath.Join(dst, "a.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "alpha", string(b))
+
+	report, err = Mirror(src, dst, MirrorOptions{DeleteExtra: true})
+	assert.NoError(t, err)
+	assert.Equal(t, want, report)
+	assert.NoFileExists(t, filepath.Join(dst, "extra.txt"))
+	assert.NoDirExists(t, filepath.Join(dst, "old"))
+	b, err = os.ReadFile(filepath.Join(dst, "a.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "alpha v2", string(b))
+
+	// Nothing left to do.
+	report, err = Mirror(src, dst, MirrorOptions{DeleteExtra: true})
+	assert.NoError(t, err)
+	assert.Equal(t, MirrorReport{Skipped: []string{"a.txt", "sub/b.txt"}}, report)
+}
+
+func TestMirrorKeepsExtraWithoutDeleteExtra(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dst, "extra.txt"), []byte("extra"), 0644))
+
+	report, err := Mirror(src, dst, MirrorOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, report.Deleted)
+	assert.FileExists(t, filepath.Join(dst, "extra.txt"))
+}
+
+func TestMirrorTypeChange(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "conf"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "conf", "app.yaml"), []byte("app"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "data"), []byte("data"), 0644))
+
+	// dst has a file where src has a directory and a directory where src has a file.
+	assert.NoError(t, os.WriteFile(filepath.Join(dst, "conf"), []byte("old"), 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dst, "data"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dst, "data", "old.txt"), []byte("old"), 0644))
+
+	want := MirrorReport{
+		Created: []string{"conf", "conf/app.yaml"},
+		Updated: []string{"data"},
+	}
+
+	report, err := Mirror(src, dst, MirrorOptions{DeleteExtra: true, DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, want, report)
+	assert.FileExists(t, filepath.Join(dst, "conf"))
+
+	report, err = Mirror(src, dst, MirrorOptions{DeleteExtra: true})
+	assert.NoError(t, err)
+	assert.Equal(t, want, report)
+
+	b, err := os.ReadFile(filepath.Join(dst, "conf", "app.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "app", string(b))
+	b, err = os.ReadFile(filepath.Join(dst, "data"))
+	assert.NoError(t, err)
+	assert.Equal(t, "data", string(b))
+}
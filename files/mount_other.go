@@ -0,0 +1,13 @@
+//go:build !unix && !windows
+
+package files
+
+import (
+	"errors"
+	"runtime"
+)
+
+// IsMountPoint is not supported on this platform and always returns an error.
+func IsMountPoint(path string) (bool, error) {
+	return false, errors.New("IsMountPoint is not supported on " + runtime.GOOS)
+}
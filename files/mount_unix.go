@@ -0,0 +1,47 @@
+//go:build unix
+
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// IsMountPoint reports whether path is the root of a mounted filesystem.
+// On unix it compares the device id of path with that of its parent directory,
+// treating the filesystem root (which is its own parent) as a mount point. Symlinks in path are resolved,
+// so a link to a mount point reports true.
+func IsMountPoint(path string) (bool, error) {
+	// Resolve symlinks first so the parent is the directory's real parent on disk,
+	// not whatever lexically precedes it in path.
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Lstat(resolved)
+	if err != nil {
+		return false, err
+	}
+	if !info.IsDir() {
+		return false, nil
+	}
+
+	parent, err := os.Lstat(filepath.Dir(resolved))
+	if err != nil {
+		return false, err
+	}
+
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	parentStat, parentOk := parent.Sys().(*syscall.Stat_t)
+	if !ok || !parentOk {
+		return false, nil
+	}
+
+	if stat.Dev != parentStat.Dev {
+		return true, nil
+	}
+
+	return stat.Ino == parentStat.Ino, nil
+}
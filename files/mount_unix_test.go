@@ -0,0 +1,64 @@
+//go:build unix
+
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMountPoint(t *testing.T) {
+	mount, err := IsMountPoint("/")
+	assert.NoError(t, err)
+	assert.True(t, mount)
+
+	dir := filepath.Join(t.TempDir(), "plain")
+	assert.NoError(t, os.Mkdir(dir, 0755))
+	mount, err = IsMountPoint(dir)
+	assert.NoError(t, err)
+	assert.False(t, mount)
+
+	_, err = IsMountPoint(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestIsMountPointThroughSymlink(t *testing.T) {
+	// The symlinked directory must live on a different filesystem than the link for the check to matter.
+	const otherFS = "/dev/shm"
+	if sameDevice(t, otherFS, t.TempDir()) {
+		t.Skipf("%s is not on a separate filesystem", otherFS)
+	}
+
+	real, err := os.MkdirTemp(otherFS, "mount-test-")
+	if err != nil {
+		t.Skipf("cannot create a directory on %s: %v", otherFS, err)
+	}
+	t.Cleanup(func() { os.RemoveAll(real) })
+	assert.NoError(t, os.Mkdir(filepath.Join(real, "sub"), 0755))
+
+	link := filepath.Join(t.TempDir(), "link")
+	assert.NoError(t, os.Symlink(real, link))
+
+	mount, err := IsMountPoint(filepath.Join(link, "sub"))
+	assert.NoError(t, err)
+	assert.False(t, mount)
+
+	mount, err = IsMountPoint(filepath.Join(real, "sub"))
+	assert.NoError(t, err)
+	assert.False(t, mount)
+}
+
+// sameDevice reports whether a and b are on the same device, treating a missing a as the same.
+func sameDevice(t *testing.T, a, b string) bool {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return true
+	}
+	infoB, err := os.Stat(b)
+	assert.NoError(t, err)
+	return infoA.Sys().(*syscall.Stat_t).Dev == infoB.Sys().(*syscall.Stat_t).Dev
+}
@@ -0,0 +1,35 @@
+//go:build windows
+
+package files
+
+import (
+	"path/filepath"
+	"syscall"
+)
+
+// IsMountPoint reports whether path is the root of a mounted filesystem.
+// On Windows drive and UNC share roots are mount points, as are directories that are
+// reparse points (volume mount folders and junctions).
+func IsMountPoint(path string) (bool, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false, err
+	}
+
+	volume := filepath.VolumeName(abs)
+	if volume != "" && (abs == volume || abs == volume+`\`) {
+		return true, nil
+	}
+
+	p, err := syscall.UTF16PtrFromString(abs)
+	if err != nil {
+		return false, err
+	}
+
+	attrs, err := syscall.GetFileAttributes(p)
+	if err != nil {
+		return false, err
+	}
+
+	return attrs&syscall.FILE_ATTRIBUTE_DIRECTORY != 0 && attrs&syscall.FILE_ATTRIBUTE_REPARSE_POINT != 0, nil
+}
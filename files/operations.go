@@ -0,0 +1,93 @@
+package files
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Write writes content to path, creating or truncating it, and sets its mode to perm.
+// Unlike os.WriteFile, perm is applied even when the file already exists.
+func Write(path string, content []byte, perm os.FileMode) error {
+	if err := os.WriteFile(path, content, perm); err != nil {
+		return err
+	}
+	return os.Chmod(path, perm)
+}
+
+// WriteAtomic writes content to a temporary file in the same directory as path, syncs it and renames it over path,
+// so readers never observe a truncated or half-written file. The file is given the mode perm, and the temporary
+// file is removed if anything fails before the rename.
+func WriteAtomic(path string, content []byte, perm os.FileMode) error {
+	return writeAtomicFunc(path, perm, func(w io.Writer) error {
+		_, err := w.Write(content)
+		return err
+	})
+}
+
+// WriteIfChanged atomically writes content to path only when it differs from the file's current content,
+// so unchanged outputs keep their modification time and don't trigger file watchers.
+// It returns whether a write took place. The mode of an existing, unchanged file is left as is.
+func WriteIfChanged(path string, content []byte, perm os.FileMode) (changed bool, err error) {
+	if GetFileSize(path) == int64(len(content)) {
+		existing, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+		if err == nil && bytes.Equal(existing, content) {
+			return false, nil
+		}
+	}
+
+	if err := WriteAtomic(path, content, perm); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ConcatFiles atomically writes the contents of srcs, in order, to dst and returns the total number of bytes written.
+func ConcatFiles(dst string, srcs ...string) (int64, error) {
+	return ConcatFilesSep(dst, nil, srcs...)
+}
+
+// ConcatFilesSep is like ConcatFiles but inserts sep between consecutive files.
+// Each source is streamed, and dst is left untouched if any source is missing or unreadable.
+func ConcatFilesSep(dst string, sep []byte, srcs ...string) (int64, error) {
+	var total int64
+	err := writeAtomicFunc(dst, 0644, func(w io.Writer) error {
+		for i, src := range srcs {
+			if i > 0 && len(sep) > 0 {
+				n, err := w.Write(sep)
+				total += int64(n)
+				if err != nil {
+					return err
+				}
+			}
+
+			n, err := appendFile(w, src)
+			total += n
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// appendFile streams the file at path into w.
+func appendFile(w io.Writer, path string) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open source %s: %w", path, err)
+	}
+	defer file.Close()
+
+	return io.Copy(w, file)
+}
@@ -0,0 +1,97 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteIfChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "generated.go")
+
+	changed, err := WriteIfChanged(path, []byte("package gen\n"), 0644)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	assert.NoError(t, os.Chtimes(path, past, past))
+
+	changed, err = WriteIfChanged(path, []byte("package gen\n"), 0644)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.True(t, past.Equal(info.ModTime()), "identical content must not touch the file")
+
+	changed, err = WriteIfChanged(path, []byte("package gen2\n"), 0644)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "package gen2\n", string(b))
+}
+
+func TestConcatFiles(t *testing.T) {
+	dir := t.TempDir()
+	var srcs []string
+	for _, name := range []string{"a", "b", "c"} {
+		path := filepath.Join(dir, name+".txt")
+		assert.NoError(t, os.WriteFile(path, []byte(name+name), 0644))
+		srcs = append(srcs, path)
+	}
+
+	dst := filepath.Join(dir, "out.txt")
+	n, err := ConcatFilesSep(dst, []byte("\n"), srcs...)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), n)
+	b, err := os.ReadFile(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, "aa\nbb\ncc", string(b))
+
+	n, err = ConcatFiles(dst, srcs...)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), n)
+
+	missing := filepath.Join(dir, "missing.txt")
+	_, err = ConcatFiles(dst, srcs[0], missing)
+	assert.ErrorContains(t, err, missing)
+	b, err = os.ReadFile(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, "aabbcc", string(b))
+}
+
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("old"), 0644))
+
+	assert.NoError(t, Write(path, []byte("new"), 0600))
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(b))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestWriteAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("old"), 0644))
+
+	assert.NoError(t, WriteAtomic(path, []byte("new"), 0600))
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(b))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary files are left behind")
+
+	assert.Error(t, WriteAtomic(filepath.Join(dir, "missing", "config.yaml"), []byte("x"), 0644))
+}
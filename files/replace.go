@@ -0,0 +1,136 @@
+package files
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// binarySniffLen is the number of leading bytes IsBinary inspects, matching what git uses.
+const binarySniffLen = 8000
+
+// ReplaceTreeOptions controls which files ReplaceInTree rewrites.
+type ReplaceTreeOptions struct {
+	// Include limits replacement to files whose relative path or base name matches one of these globs.
+	// An empty list includes every file.
+	Include []string
+	// Exclude skips files and directories whose relative path or base name matches any of these globs.
+	Exclude []string
+	// SkipBinary leaves files detected by IsBinary untouched so they are not corrupted.
+	SkipBinary bool
+}
+
+// IsBinary reports whether the file at path looks binary, i.e. contains a NUL byte within its first 8000 bytes.
+func IsBinary(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	buf := make([]byte, binarySniffLen)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return false, err
+	}
+
+	return bytes.IndexByte(buf[:n], 0) != -1, nil
+}
+
+// ReplaceInTree replaces every occurrence of old with replacement in the regular files under root.
+// Each changed file is rewritten atomically with its original mode. Symlinks are not followed.
+//
+// Arguments:
+//   - root: the directory to process
+//   - old: the string to search for (must not be empty)
+//   - replacement: the string to replace it with
+//   - opts: the include/exclude globs and binary handling
+//
+// Returns:
+//   - the paths of the files that were modified
+//   - an error if a file could not be read or written
+func ReplaceInTree(root, old, replacement string, opts ReplaceTreeOptions) (changedFiles []string, err error) {
+	if old == "" {
+		return nil, errors.New("search string must not be empty")
+	}
+
+	oldBytes, newBytes := []byte(old), []byte(replacement)
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		if path != root && matchesAnyGlob(opts.Exclude, rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		if len(opts.Include) > 0 && !matchesAnyGlob(opts.Include, rel) {
+			return nil
+		}
+
+		if opts.SkipBinary {
+			binary, err := IsBinary(path)
+			if err != nil {
+				return err
+			}
+			if binary {
+				return nil
+			}
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !bytes.Contains(content, oldBytes) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		updated := bytes.ReplaceAll(content, oldBytes, newBytes)
+		err = writeAtomicFunc(path, info.Mode().Perm(), func(w io.Writer) error {
+			_, err := w.Write(updated)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		changedFiles = append(changedFiles, path)
+		return nil
+	})
+
+	return changedFiles, err
+}
+
+// matchesAnyGlob reports whether rel or its base name matches any of the glob patterns.
+func matchesAnyGlob(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, filepath.Base(rel)); ok {
+			return true
+		}
+	}
+	return false
+}
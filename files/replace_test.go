@@ -0,0 +1,61 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceInTree(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "pkg"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "vendor"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package oldname\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "pkg", "README.md"), []byte("# oldname\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "pkg", "logo.bin"), []byte("oldname\x00\x01"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "vendor", "dep.go"), []byte("oldname"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "notes.txt"), []byte("nothing here"), 0644))
+
+	changed, err := ReplaceInTree(root, "oldname", "newname", ReplaceTreeOptions{
+		Exclude:    []string{"vendor"},
+		SkipBinary: true,
+	})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(root, "main.go"),
+		filepath.Join(root, "pkg", "README.md"),
+	}, changed)
+
+	b, _ := os.ReadFile(filepath.Join(root, "pkg", "README.md"))
+	assert.Equal(t, "# newname\n", string(b))
+	info, err := os.Stat(filepath.Join(root, "pkg", "README.md"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	b, _ = os.ReadFile(filepath.Join(root, "pkg", "logo.bin"))
+	assert.Equal(t, "oldname\x00\x01", string(b))
+	b, _ = os.ReadFile(filepath.Join(root, "vendor", "dep.go"))
+	assert.Equal(t, "oldname", string(b))
+
+	changed, err = ReplaceInTree(root, "newname", "other", ReplaceTreeOptions{Include: []string{"*.go"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(root, "main.go")}, changed)
+}
+
+func TestIsBinary(t *testing.T) {
+	dir := t.TempDir()
+	text := filepath.Join(dir, "a.txt")
+	binary := filepath.Join(dir, "a.bin")
+	assert.NoError(t, os.WriteFile(text, []byte("plain text"), 0644))
+	assert.NoError(t, os.WriteFile(binary, []byte{0x89, 'P', 'N', 'G', 0x00}, 0644))
+
+	isBinary, err := IsBinary(text)
+	assert.NoError(t, err)
+	assert.False(t, isBinary)
+
+	isBinary, err = IsBinary(binary)
+	assert.NoError(t, err)
+	assert.True(t, isBinary)
+}
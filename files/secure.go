@@ -0,0 +1,110 @@
+package files
+
+import (
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/mateothegreat/go-multilog/multilog"
+)
+
+// DestroyFile overwrites the contents of the file at path with random bytes, syncs it to disk and removes it.
+// This makes it harder to recover sensitive data from the freed blocks, although it cannot guarantee
+// erasure on copy-on-write or journaling filesystems and SSDs.
+func DestroyFile(path string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.CopyN(file, rand.Reader, stat.Size()); err != nil {
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+// SecureTempFile creates an empty temporary file with mode 0600 in a per-user directory with mode 0700,
+// suitable for holding secrets. The directory lives under os.UserCacheDir. When that is unavailable a fresh
+// private directory is created under os.TempDir with os.MkdirTemp, since a fixed path in the shared temp
+// directory could be pre-created by another user; cleanup removes that directory as well.
+//
+// Arguments:
+//   - prefix: the prefix of the generated file name
+//
+// Returns:
+//   - the path of the created file
+//   - a cleanup function that destroys the file with DestroyFile, logging any failure; it is safe to call more than once
+//   - an error if the directory or file could not be created
+func SecureTempFile(prefix string) (path string, cleanup func(), err error) {
+	dir, removeDir, err := secureTempDir()
+	if err != nil {
+		return "", nil, err
+	}
+
+	file, err := os.CreateTemp(dir, prefix+"*")
+	if err != nil {
+		removeDir()
+		return "", nil, err
+	}
+	defer file.Close()
+
+	if err := file.Chmod(0600); err != nil {
+		os.Remove(file.Name())
+		removeDir()
+		return "", nil, err
+	}
+
+	path = file.Name()
+	var once sync.Once
+	return path, func() {
+		once.Do(func() {
+			if err := DestroyFile(path); err != nil {
+				multilog.Error("files.SecureTempFile", "failed to destroy temp file", map[string]interface{}{
+					"path":  path,
+					"error": err,
+				})
+			}
+			removeDir()
+		})
+	}, nil
+}
+
+// secureTempDir returns a directory with mode 0700 owned by the current user for SecureTempFile,
+// along with a function that removes it if it was created just for this file.
+func secureTempDir() (dir string, remove func(), err error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		dir, err := os.MkdirTemp("", "go-util-secure-")
+		if err != nil {
+			return "", nil, err
+		}
+		return dir, func() { os.RemoveAll(dir) }, nil
+	}
+
+	dir = filepath.Join(base, "go-util", "secure")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", nil, err
+	}
+	if err := os.Chmod(dir, 0700); err != nil {
+		return "", nil, err
+	}
+
+	return dir, func() {}, nil
+}
@@ -0,0 +1,60 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecureTempFile(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	path, cleanup, err := SecureTempFile("secret-")
+	assert.NoError(t, err)
+	assert.Contains(t, filepath.Base(path), "secret-")
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	assert.NoError(t, os.WriteFile(path, []byte("top secret"), 0600))
+	cleanup()
+	assert.False(t, FileExists(path))
+	cleanup() // safe to call again
+}
+
+func TestSecureTempFileFallback(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("os.UserCacheDir only depends on the environment on linux")
+	}
+	tmp := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", "")
+	t.Setenv("HOME", "")
+	t.Setenv("TMPDIR", tmp)
+
+	path, cleanup, err := SecureTempFile("secret-")
+	assert.NoError(t, err)
+
+	dir := filepath.Dir(path)
+	assert.Equal(t, tmp, filepath.Dir(dir))
+	info, err := os.Stat(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	cleanup()
+	assert.NoDirExists(t, dir)
+}
+
+func TestDestroyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	assert.NoError(t, os.WriteFile(path, []byte("private key"), 0600))
+
+	assert.NoError(t, DestroyFile(path))
+	assert.False(t, FileExists(path))
+	assert.Error(t, DestroyFile(path))
+}
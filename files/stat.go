@@ -0,0 +1,48 @@
+package files
+
+import (
+	"os"
+	"time"
+)
+
+// FileMeta describes a file in a single struct, gathered by Stat.
+type FileMeta struct {
+	Path      string
+	Size      int64
+	Mode      os.FileMode
+	ModTime   time.Time
+	IsDir     bool
+	IsSymlink bool
+	// Uid and Gid are the owning user and group ids. They are always zero on Windows.
+	Uid uint32
+	Gid uint32
+}
+
+// Stat returns the metadata for the file at path.
+// IsSymlink reports whether path itself is a symlink; the remaining fields describe the
+// link target, or the link itself when the target does not exist.
+func Stat(path string) (*FileMeta, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	isSymlink := info.Mode()&os.ModeSymlink != 0
+	if isSymlink {
+		if target, err := os.Stat(path); err == nil {
+			info = target
+		}
+	}
+
+	meta := &FileMeta{
+		Path:      path,
+		Size:      info.Size(),
+		Mode:      info.Mode(),
+		ModTime:   info.ModTime(),
+		IsDir:     info.IsDir(),
+		IsSymlink: isSymlink,
+	}
+	meta.Uid, meta.Gid = fileOwner(info)
+
+	return meta, nil
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package files
+
+import "os"
+
+// fileOwner returns zero ids since file ownership is not exposed as uid/gid on this platform.
+func fileOwner(info os.FileInfo) (uint32, uint32) {
+	return 0, 0
+}
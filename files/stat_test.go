@@ -0,0 +1,46 @@
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStat(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("hello"), 0640))
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NoError(t, os.Chtimes(path, modTime, modTime))
+
+	meta, err := Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, path, meta.Path)
+	assert.Equal(t, int64(5), meta.Size)
+	assert.Equal(t, os.FileMode(0640), meta.Mode.Perm())
+	assert.True(t, modTime.Equal(meta.ModTime))
+	assert.False(t, meta.IsDir)
+	assert.False(t, meta.IsSymlink)
+	if runtime.GOOS != "windows" {
+		assert.Equal(t, uint32(os.Getuid()), meta.Uid)
+		assert.Equal(t, uint32(os.Getgid()), meta.Gid)
+	}
+
+	link := filepath.Join(dir, "link")
+	assert.NoError(t, os.Symlink(path, link))
+	meta, err = Stat(link)
+	assert.NoError(t, err)
+	assert.True(t, meta.IsSymlink)
+	assert.Equal(t, int64(5), meta.Size)
+
+	meta, err = Stat(dir)
+	assert.NoError(t, err)
+	assert.True(t, meta.IsDir)
+
+	_, err = Stat(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
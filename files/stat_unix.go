@@ -0,0 +1,16 @@
+//go:build unix
+
+package files
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileOwner returns the uid and gid recorded in info.
+func fileOwner(info os.FileInfo) (uint32, uint32) {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return stat.Uid, stat.Gid
+	}
+	return 0, 0
+}
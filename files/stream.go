@@ -0,0 +1,77 @@
+package files
+
+import (
+	"errors"
+	"io"
+)
+
+// defaultStreamBufferSize matches the buffer size used by io.Copy.
+const defaultStreamBufferSize = 32 * 1024
+
+// ErrStreamLimitExceeded is returned by CopyStream when the source holds more than StreamOptions.MaxBytes.
+var ErrStreamLimitExceeded = errors.New("stream exceeds maximum size")
+
+// StreamOptions controls CopyStream.
+type StreamOptions struct {
+	// MaxBytes is the maximum number of bytes to copy. Zero means no limit.
+	MaxBytes int64
+	// BufferSize is the size of the copy buffer. Zero uses a 32KiB buffer.
+	BufferSize int
+	// OnProgress, when set, is called after each write with the total number of bytes copied so far.
+	OnProgress func(copied int64)
+}
+
+// CopyStream copies from src to dst until EOF, honouring the limit, buffer size and progress callback in opts.
+// When the limit is exceeded, the bytes up to the limit are written and ErrStreamLimitExceeded is returned.
+//
+// Arguments:
+//   - dst: the writer to copy to
+//   - src: the reader to copy from
+//   - opts: the copy options
+//
+// Returns:
+//   - the number of bytes written to dst
+//   - an error if reading or writing failed or the limit was exceeded
+func CopyStream(dst io.Writer, src io.Reader, opts StreamOptions) (int64, error) {
+	size := opts.BufferSize
+	if size <= 0 {
+		size = defaultStreamBufferSize
+	}
+	buf := make([]byte, size)
+
+	var copied int64
+	for {
+		n, readErr := src.Read(buf)
+		if n > 0 {
+			chunk := buf[:n]
+			exceeded := opts.MaxBytes > 0 && copied+int64(n) > opts.MaxBytes
+			if exceeded {
+				chunk = chunk[:opts.MaxBytes-copied]
+			}
+
+			written, err := dst.Write(chunk)
+			copied += int64(written)
+			if err == nil && written != len(chunk) {
+				err = io.ErrShortWrite
+			}
+			if err != nil {
+				return copied, err
+			}
+
+			if opts.OnProgress != nil {
+				opts.OnProgress(copied)
+			}
+
+			if exceeded {
+				return copied, ErrStreamLimitExceeded
+			}
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			return copied, nil
+		}
+		if readErr != nil {
+			return copied, readErr
+		}
+	}
+}
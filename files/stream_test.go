@@ -0,0 +1,39 @@
+package files
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyStream(t *testing.T) {
+	src := strings.NewReader(strings.Repeat("x", 100))
+	var dst bytes.Buffer
+	var progress []int64
+
+	n, err := CopyStream(&dst, src, StreamOptions{
+		BufferSize: 30,
+		OnProgress: func(copied int64) { progress = append(progress, copied) },
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), n)
+	assert.Equal(t, 100, dst.Len())
+	assert.Equal(t, []int64{30, 60, 90, 100}, progress)
+}
+
+func TestCopyStreamLimit(t *testing.T) {
+	src := strings.NewReader(strings.Repeat("x", 100))
+	var dst bytes.Buffer
+
+	n, err := CopyStream(&dst, src, StreamOptions{MaxBytes: 50, BufferSize: 16})
+	assert.ErrorIs(t, err, ErrStreamLimitExceeded)
+	assert.Equal(t, int64(50), n)
+	assert.Equal(t, 50, dst.Len())
+
+	dst.Reset()
+	n, err = CopyStream(&dst, strings.NewReader("exact"), StreamOptions{MaxBytes: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+}
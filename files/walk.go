@@ -0,0 +1,85 @@
+package files
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// WalkConcurrent walks the tree rooted at root and calls fn for every non-directory entry
+// using a pool of concurrency workers. Directory traversal stays serial while fn calls run in parallel,
+// which suits CPU-bound per-file work such as hashing or parsing.
+// The first error returned by fn cancels the walk; all errors returned by fn are joined in the result.
+//
+// Arguments:
+//   - root: the directory to walk
+//   - concurrency: the number of workers (runtime.NumCPU() when less than 1)
+//   - fn: the function called for each file; it may be called from multiple goroutines
+//
+// Returns:
+//   - the joined errors from fn, or the traversal error if the walk itself failed
+func WalkConcurrent(root string, concurrency int, fn func(path string, d os.DirEntry) error) error {
+	if concurrency < 1 {
+		concurrency = runtime.NumCPU()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type job struct {
+		path string
+		d    os.DirEntry
+	}
+	jobs := make(chan job)
+
+	var mu sync.Mutex
+	var errs []error
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := fn(j.path, j.d); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+					cancel()
+				}
+			}
+		}()
+	}
+
+	walkErr := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		select {
+		case jobs <- job{path, d}:
+			return nil
+		case <-ctx.Done():
+			return filepath.SkipAll
+		}
+	})
+
+	close(jobs)
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return walkErr
+}
@@ -0,0 +1,62 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTree(tb testing.TB, root string, dirs, filesPerDir int) {
+	for i := 0; i < dirs; i++ {
+		dir := filepath.Join(root, fmt.Sprintf("dir%d", i))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			tb.Fatal(err)
+		}
+		for j := 0; j < filesPerDir; j++ {
+			content := []byte(fmt.Sprintf("file %d/%d", i, j))
+			if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("file%d.txt", j)), content, 0644); err != nil {
+				tb.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestWalkConcurrent(t *testing.T) {
+	root := t.TempDir()
+	makeTree(t, root, 5, 20)
+
+	var count int64
+	err := WalkConcurrent(root, 4, func(path string, d os.DirEntry) error {
+		atomic.AddInt64(&count, 1)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), count)
+
+	boom := errors.New("boom")
+	err = WalkConcurrent(root, 4, func(path string, d os.DirEntry) error {
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+}
+
+func BenchmarkWalkConcurrent(b *testing.B) {
+	root := b.TempDir()
+	makeTree(b, root, 50, 100)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := WalkConcurrent(root, 0, func(path string, d os.DirEntry) error {
+			_, err := SHA256(path)
+			return err
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package files
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mateothegreat/go-multilog/multilog"
+)
+
+// watchInterval is how often WatchConfig polls the config file for changes.
+var watchInterval = 250 * time.Millisecond
+
+// watchDebounce is how long the config file must stay unchanged before WatchConfig re-parses it,
+// so editors that write in several steps only trigger a single reload.
+var watchDebounce = 200 * time.Millisecond
+
+// WatchConfig parses the config file at path and calls onChange with the result, then polls the file
+// and calls onChange again with the re-parsed value whenever it changes (debounced).
+// Files ending in ".json" (optionally ".json.gz") are decoded as JSON and anything else as YAML.
+// Parse errors during a reload are logged and the last good value stays in effect.
+//
+// Arguments:
+//   - ctx: cancelling the context stops watching
+//   - path: the config file to watch
+//   - onChange: called with each successfully parsed value, including the initial one
+//
+// Returns:
+//   - an error if the initial parse fails, otherwise nil once ctx is cancelled
+func WatchConfig[T any](ctx context.Context, path string, onChange func(*T)) error {
+	config, err := decodeConfigFile[T](path)
+	if err != nil {
+		return err
+	}
+	onChange(config)
+
+	last, _ := fileSignature(path)
+	pending := false
+	var changedAt time.Time
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			signature, err := fileSignature(path)
+			if err != nil {
+				// The file may be briefly missing while it is being replaced.
+				continue
+			}
+
+			if signature != last {
+				last = signature
+				pending = true
+				changedAt = time.Now()
+				continue
+			}
+
+			if !pending || time.Since(changedAt) < watchDebounce {
+				continue
+			}
+			pending = false
+
+			config, err := decodeConfigFile[T](path)
+			if err != nil {
+				multilog.Error("files.WatchConfig", "failed to reload config", map[string]interface{}{
+					"path":  path,
+					"error": err,
+				})
+				continue
+			}
+			onChange(config)
+		}
+	}
+}
+
+// decodeConfigFile decodes path as JSON when it has a ".json" extension (ignoring ".gz") and as YAML otherwise.
+func decodeConfigFile[T any](path string) (*T, error) {
+	if strings.HasSuffix(strings.TrimSuffix(path, ".gz"), ".json") {
+		return JSONFromFile[T](path)
+	}
+	return YAMLFromFile[T](path)
+}
+
+// signature identifies a version of a file by its modification time and size.
+type signature struct {
+	modTime time.Time
+	size    int64
+}
+
+// fileSignature returns the current signature of the file at path.
+func fileSignature(path string) (signature, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return signature{}, err
+	}
+	return signature{info.ModTime(), info.Size()}, nil
+}
@@ -0,0 +1,54 @@
+package files
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchConfig(t *testing.T) {
+	interval, debounce := watchInterval, watchDebounce
+	watchInterval, watchDebounce = 10*time.Millisecond, 20*time.Millisecond
+	defer func() { watchInterval, watchDebounce = interval, debounce }()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("name: first\nport: 1\n"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan *decodeConfig, 10)
+	done := make(chan error)
+	go func() {
+		done <- WatchConfig(ctx, path, func(c *decodeConfig) { updates <- c })
+	}()
+
+	select {
+	case c := <-updates:
+		assert.Equal(t, "first", c.Name)
+	case <-time.After(time.Second):
+		t.Fatal("initial config was not delivered")
+	}
+
+	// A broken file keeps the last good value and is not delivered.
+	assert.NoError(t, os.WriteFile(path, []byte("name: [broken\n"), 0644))
+	future := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(path, future, future))
+	time.Sleep(100 * time.Millisecond)
+
+	assert.NoError(t, os.WriteFile(path, []byte("name: second\nport: 2\n"), 0644))
+	future = future.Add(time.Minute)
+	assert.NoError(t, os.Chtimes(path, future, future))
+
+	select {
+	case c := <-updates:
+		assert.Equal(t, &decodeConfig{Name: "second", Port: 2}, c)
+	case <-time.After(2 * time.Second):
+		t.Fatal("updated config was not delivered")
+	}
+
+	cancel()
+	assert.NoError(t, <-done)
+}
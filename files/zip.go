@@ -0,0 +1,247 @@
+package files
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mateothegreat/go-util/paths"
+)
+
+// ZipDir writes the contents of srcDir to dstZip with entry names relative to srcDir.
+// File modes and modification times are preserved and empty directories are included,
+// so Unzip recreates the same structure. Symlinks are skipped.
+func ZipDir(srcDir, dstZip string) error {
+	return writeZip(srcDir, dstZip, func(rel string, info fs.FileInfo) (*zip.FileHeader, error) {
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return nil, err
+		}
+		header.Name = rel
+		if info.IsDir() {
+			header.Method = zip.Store
+		} else {
+			header.Method = zip.Deflate
+		}
+		return header, nil
+	})
+}
+
+// ZipReproducible writes the contents of srcDir to dstZip so that the same input
+// tree always produces a byte-identical archive.
+// Entries are sorted by path, modification times are zeroed and permissions are
+// fixed to 0755 for directories and 0644 for files. Symlinks are skipped.
+//
+// Arguments:
+//   - srcDir: the directory to archive
+//   - dstZip: the path of the zip file to create
+//
+// Returns:
+//   - an error if the tree could not be read or the archive could not be written
+func ZipReproducible(srcDir, dstZip string) error {
+	return writeZip(srcDir, dstZip, func(rel string, info fs.FileInfo) (*zip.FileHeader, error) {
+		header := &zip.FileHeader{
+			Name:   rel,
+			Method: zip.Deflate,
+		}
+		if info.IsDir() {
+			header.Method = zip.Store
+			header.SetMode(fs.ModeDir | 0755)
+		} else {
+			header.SetMode(0644)
+		}
+		return header, nil
+	})
+}
+
+// UnzipOptions controls UnzipWithOptions.
+type UnzipOptions struct {
+	// MaxBytes caps the total number of bytes extracted across all entries, guarding against zip bombs.
+	// Extraction stops with an error wrapping ErrStreamLimitExceeded once the cap is reached. Zero means no limit.
+	MaxBytes int64
+}
+
+// Unzip extracts srcZip into dstDir, creating dstDir if needed and restoring file modes and modification times.
+// Every entry is checked to stay within dstDir after cleaning, and an entry that would escape it (Zip Slip)
+// aborts the extraction with an error. Symlink entries are not extracted.
+// The extracted size is not limited; use UnzipWithOptions with MaxBytes for untrusted archives.
+func Unzip(srcZip, dstDir string) error {
+	return UnzipWithOptions(srcZip, dstDir, UnzipOptions{})
+}
+
+// UnzipWithOptions extracts srcZip into dstDir like Unzip, honouring the limits in opts.
+func UnzipWithOptions(srcZip, dstDir string, opts UnzipOptions) error {
+	r, err := zip.OpenReader(srcZip)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(dstDir, 0755); err != nil {
+		return err
+	}
+
+	var extracted int64
+	for _, f := range r.File {
+		target := filepath.Join(dstDir, filepath.FromSlash(f.Name))
+		if !paths.IsSubPath(target, dstDir) {
+			return fmt.Errorf("zip entry %q escapes the destination directory", f.Name)
+		}
+
+		mode := f.Mode()
+		switch {
+		case mode&fs.ModeSymlink != 0:
+			continue
+		case mode.IsDir():
+			if err := os.MkdirAll(target, defaultPerm(mode, 0755)); err != nil {
+				return err
+			}
+		default:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+
+			var remaining int64
+			if opts.MaxBytes > 0 {
+				remaining = opts.MaxBytes - extracted
+				if remaining <= 0 {
+					return fmt.Errorf("extracting %q: %w", f.Name, ErrStreamLimitExceeded)
+				}
+			}
+
+			n, err := extractFile(f, target, remaining)
+			extracted += n
+			if err != nil {
+				return fmt.Errorf("extracting %q: %w", f.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeZip archives every entry under srcDir into dstZip in sorted order, using header to build
+// the zip header for each entry from its slash-separated relative path. Symlinks are skipped.
+func writeZip(srcDir, dstZip string, header func(rel string, info fs.FileInfo) (*zip.FileHeader, error)) error {
+	var entries []string
+	err := filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == srcDir || d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+		entries = append(entries, path)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(entries)
+
+	out, err := os.Create(dstZip)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	w := zip.NewWriter(out)
+	for _, path := range entries {
+		info, err := os.Lstat(path)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if info.IsDir() {
+			rel += "/"
+		}
+
+		h, err := header(rel, info)
+		if err != nil {
+			return err
+		}
+
+		entry, err := w.CreateHeader(h)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			continue
+		}
+
+		if err := copyInto(entry, path); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return out.Close()
+}
+
+// extractFile writes the content of the zip entry f to target with the entry's mode and modification time.
+// At most maxBytes are written when maxBytes is positive. It returns the number of bytes written.
+func extractFile(f *zip.File, target string, maxBytes int64) (int64, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	perm := defaultPerm(f.Mode(), 0644)
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+
+	written, err := CopyStream(out, rc, StreamOptions{MaxBytes: maxBytes})
+	if err != nil {
+		return written, err
+	}
+
+	// OpenFile applies the umask, so set the archived permissions explicitly.
+	if err := out.Chmod(perm); err != nil {
+		return written, err
+	}
+
+	if err := out.Close(); err != nil {
+		return written, err
+	}
+
+	if f.Modified.IsZero() {
+		return written, nil
+	}
+	return written, os.Chtimes(target, f.Modified, f.Modified)
+}
+
+// defaultPerm returns the permission bits of mode, or def for archives that do not record them.
+func defaultPerm(mode fs.FileMode, def fs.FileMode) fs.FileMode {
+	if mode.Perm() == 0 {
+		return def
+	}
+	return mode.Perm()
+}
+
+// copyInto streams the file at path into w.
+func copyInto(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -0,0 +1,131 @@
+package files
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZipReproducible(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "nested", "empty"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("a"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "nested", "b.txt"), []byte("b"), 0600))
+
+	first := filepath.Join(dir, "first.zip")
+	assert.NoError(t, ZipReproducible(src, first))
+
+	later := time.Now().Add(time.Hour)
+	assert.NoError(t, os.Chtimes(filepath.Join(src, "a.txt"), later, later))
+
+	second := filepath.Join(dir, "second.zip")
+	assert.NoError(t, ZipReproducible(src, second))
+
+	a, err := os.ReadFile(first)
+	assert.NoError(t, err)
+	b, err := os.ReadFile(second)
+	assert.NoError(t, err)
+	assert.Equal(t, sha256.Sum256(a), sha256.Sum256(b))
+}
+
+func TestZipDirUnzip(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "nested", "empty"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("alpha"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "nested", "run.sh"), []byte("#!/bin/sh\n"), 0750))
+	if err := os.Symlink("a.txt", filepath.Join(src, "link.txt")); err != nil {
+		t.Logf("symlinks not supported: %v", err)
+	}
+
+	archive := filepath.Join(dir, "out.zip")
+	assert.NoError(t, ZipDir(src, archive))
+
+	dst := filepath.Join(dir, "dst")
+	assert.NoError(t, Unzip(archive, dst))
+
+	b, err := os.ReadFile(filepath.Join(dst, "nested", "run.sh"))
+	assert.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\n", string(b))
+	assert.DirExists(t, filepath.Join(dst, "nested", "empty"))
+	assert.NoFileExists(t, filepath.Join(dst, "link.txt"))
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(filepath.Join(dst, "nested", "run.sh"))
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0750), info.Mode().Perm())
+	}
+
+	// HashDir covers symlinks too, so drop the skipped link before comparing the trees.
+	_ = os.Remove(filepath.Join(src, "link.txt"))
+	srcHash, err := HashDir(src)
+	assert.NoError(t, err)
+	dstHash, err := HashDir(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, srcHash, dstHash)
+}
+
+func TestUnzipRejectsZipSlip(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "evil.zip")
+
+	writeTestZip(t, archive, []byte("pwned"), &zip.FileHeader{Name: "../evil.txt"})
+
+	err := Unzip(archive, filepath.Join(dir, "dst"))
+	assert.ErrorContains(t, err, "escapes")
+	assert.NoFileExists(t, filepath.Join(dir, "evil.txt"))
+}
+
+// writeTestZip writes a zip at path with one entry per header, each holding content.
+func writeTestZip(t *testing.T, path string, content []byte, headers ...*zip.FileHeader) {
+	out, err := os.Create(path)
+	assert.NoError(t, err)
+	w := zip.NewWriter(out)
+	for _, header := range headers {
+		entry, err := w.CreateHeader(header)
+		assert.NoError(t, err)
+		_, err = entry.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	assert.NoError(t, out.Close())
+}
+
+func TestUnzipDefaultsMissingPermissions(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "noperm.zip")
+	// A unix creator with no external attributes records mode 0000.
+	writeTestZip(t, archive, []byte("data"), &zip.FileHeader{Name: "a.txt", CreatorVersion: 3 << 8})
+
+	dst := filepath.Join(dir, "dst")
+	assert.NoError(t, Unzip(archive, dst))
+
+	b, err := os.ReadFile(filepath.Join(dst, "a.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "data", string(b))
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(filepath.Join(dst, "a.txt"))
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+	}
+}
+
+func TestUnzipWithOptionsMaxBytes(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "big.zip")
+	writeTestZip(t, archive, make([]byte, 1024), &zip.FileHeader{Name: "a.bin"}, &zip.FileHeader{Name: "b.bin"})
+
+	assert.NoError(t, UnzipWithOptions(archive, filepath.Join(dir, "fits"), UnzipOptions{MaxBytes: 2048}))
+
+	err := UnzipWithOptions(archive, filepath.Join(dir, "capped"), UnzipOptions{MaxBytes: 1500})
+	assert.ErrorIs(t, err, ErrStreamLimitExceeded)
+	assert.ErrorContains(t, err, "b.bin")
+}
@@ -0,0 +1,75 @@
+package paths
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveActualCase returns path with each component spelled exactly as it is on disk.
+// Components are matched case-insensitively against their parent directory listing, with an exact
+// match preferred when several entries differ only by case. An error is returned if a component has
+// no case-insensitive match. Relative paths are resolved against the working directory but returned relative.
+func ResolveActualCase(path string) (string, error) {
+	path = filepath.Clean(path)
+	volume := filepath.VolumeName(path)
+	rest := path[len(volume):]
+
+	resolved := volume
+	dir := volume
+	if strings.HasPrefix(rest, string(filepath.Separator)) {
+		resolved += string(filepath.Separator)
+		dir = resolved
+	} else if dir == "" {
+		dir = "."
+	}
+
+	for _, component := range strings.Split(rest, string(filepath.Separator)) {
+		if component == "" || component == "." {
+			continue
+		}
+
+		actual, err := matchComponent(dir, component)
+		if err != nil {
+			return "", err
+		}
+
+		resolved = filepath.Join(resolved, actual)
+		dir = filepath.Join(dir, actual)
+	}
+
+	if resolved == "" {
+		return ".", nil
+	}
+
+	return resolved, nil
+}
+
+// matchComponent returns the name of the entry in dir that matches name case-insensitively.
+func matchComponent(dir, name string) (string, error) {
+	if name == ".." {
+		return name, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	match := ""
+	for _, entry := range entries {
+		if entry.Name() == name {
+			return name, nil
+		}
+		if match == "" && strings.EqualFold(entry.Name(), name) {
+			match = entry.Name()
+		}
+	}
+
+	if match == "" {
+		return "", fmt.Errorf("no case-insensitive match for %q in %s", name, dir)
+	}
+
+	return match, nil
+}
@@ -0,0 +1,44 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveActualCase(t *testing.T) {
+	root := t.TempDir()
+	actual := filepath.Join(root, "Projects", "MyApp", "README.md")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(actual), 0755))
+	assert.NoError(t, os.WriteFile(actual, nil, 0644))
+
+	wrong := filepath.Join(root, "projects", "MYAPP", "readme.MD")
+	resolved, err := ResolveActualCase(wrong)
+	assert.NoError(t, err)
+	assert.Equal(t, actual, resolved)
+
+	_, err = ResolveActualCase(filepath.Join(root, "projects", "missing"))
+	assert.ErrorContains(t, err, "missing")
+}
+
+func TestResolveActualCaseRelative(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "Src", "Lib"), 0755))
+	chdir(t, root)
+
+	resolved, err := ResolveActualCase(strings.ToLower(filepath.Join("Src", "Lib")))
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("Src", "Lib"), resolved)
+}
+
+// chdir changes the working directory to dir for the rest of the test.
+// It stands in for t.Chdir, which needs go 1.24.
+func chdir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
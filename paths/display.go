@@ -0,0 +1,53 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Shorten replaces the user's home directory prefix in path with "~" for display.
+// Paths outside the home directory are returned unchanged.
+func Shorten(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+
+	if path == home {
+		return "~"
+	}
+
+	if strings.HasPrefix(path, home+string(filepath.Separator)) {
+		return "~" + path[len(home):]
+	}
+
+	return path
+}
+
+// ShortenToWidth elides the middle of path with "…" so that it fits within maxLen characters.
+// The basename is always kept intact, so the result may exceed maxLen when the basename alone is longer.
+//
+// Arguments:
+//   - path: the path to shorten
+//   - maxLen: the maximum length of the result in characters (runes)
+//
+// Returns:
+//   - the shortened path, or path itself when it already fits
+func ShortenToWidth(path string, maxLen int) string {
+	runes := []rune(path)
+	if len(runes) <= maxLen {
+		return path
+	}
+
+	base := []rune(filepath.Base(path))
+	tail := append([]rune(string(filepath.Separator)), base...)
+
+	// Leave room for the ellipsis between the kept head and the tail.
+	head := maxLen - len(tail) - 1
+	if head <= 0 {
+		return string(base)
+	}
+
+	return string(runes[:head]) + "…" + string(tail)
+}
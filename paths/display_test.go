@@ -0,0 +1,32 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShorten(t *testing.T) {
+	home, err := os.UserHomeDir()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "~", Shorten(home))
+	assert.Equal(t, filepath.Join("~", "projects", "app"), Shorten(filepath.Join(home, "projects", "app")))
+	assert.Equal(t, "/opt/app", Shorten("/opt/app"))
+	assert.Equal(t, home+"suffix", Shorten(home+"suffix"))
+}
+
+func TestShortenToWidth(t *testing.T) {
+	path := "/very/long/directory/structure/file.txt"
+
+	assert.Equal(t, path, ShortenToWidth(path, 100))
+
+	short := ShortenToWidth(path, 20)
+	assert.Equal(t, "/very/long…/file.txt", short)
+	assert.Equal(t, 20, utf8.RuneCountInString(short))
+
+	assert.Equal(t, "file.txt", ShortenToWidth(path, 5))
+}
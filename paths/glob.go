@@ -0,0 +1,26 @@
+package paths
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// GlobRel matches pattern under base and returns the matches as paths relative to base, sorted for determinism.
+// The pattern syntax is that of filepath.Match and may contain separators, e.g. "cmd/*/main.go".
+// Only pattern is interpreted as a glob, so base may safely contain characters such as '[' or '*'.
+func GlobRel(base, pattern string) ([]string, error) {
+	matches, err := fs.Glob(os.DirFS(base), filepath.ToSlash(pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	rel := make([]string, 0, len(matches))
+	for _, match := range matches {
+		rel = append(rel, filepath.FromSlash(match))
+	}
+
+	sort.Strings(rel)
+	return rel, nil
+}
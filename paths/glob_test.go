@@ -0,0 +1,42 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobRel(t *testing.T) {
+	base := t.TempDir()
+	for _, name := range []string{"b.go", "a.go", "c.txt", filepath.Join("cmd", "tool", "main.go"), filepath.Join("cmd", "app", "main.go")} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(base, filepath.Dir(name)), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(base, name), nil, 0644))
+	}
+
+	got, err := GlobRel(base, "*.go")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.go", "b.go"}, got)
+
+	got, err = GlobRel(base, filepath.Join("cmd", "*", "main.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("cmd", "app", "main.go"), filepath.Join("cmd", "tool", "main.go")}, got)
+
+	got, err = GlobRel(base, "*.rs")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = GlobRel(base, "[")
+	assert.Error(t, err)
+}
+
+func TestGlobRelMetacharactersInBase(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "build[1]")
+	assert.NoError(t, os.MkdirAll(base, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(base, "out.bin"), nil, 0644))
+
+	got, err := GlobRel(base, "*.bin")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"out.bin"}, got)
+}
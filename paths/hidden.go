@@ -0,0 +1,18 @@
+package paths
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// IsHidden reports whether path refers to a hidden file or directory.
+// A base name starting with "." is hidden on every platform; on Windows the
+// FILE_ATTRIBUTE_HIDDEN attribute is checked as well, which requires path to exist.
+func IsHidden(path string) (bool, error) {
+	name := filepath.Base(path)
+	if strings.HasPrefix(name, ".") && name != "." && name != ".." {
+		return true, nil
+	}
+
+	return hasHiddenAttribute(path)
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package paths
+
+// hasHiddenAttribute always reports false since only the dotfile convention applies outside Windows.
+func hasHiddenAttribute(path string) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,33 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsHidden(t *testing.T) {
+	dir := t.TempDir()
+	dotfile := filepath.Join(dir, ".env")
+	visible := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, os.WriteFile(dotfile, nil, 0644))
+	assert.NoError(t, os.WriteFile(visible, nil, 0644))
+
+	hidden, err := IsHidden(dotfile)
+	assert.NoError(t, err)
+	assert.True(t, hidden)
+
+	hidden, err = IsHidden(filepath.Join(dir, ".git", "config"))
+	assert.NoError(t, err)
+	assert.False(t, hidden)
+
+	hidden, err = IsHidden(visible)
+	assert.NoError(t, err)
+	assert.False(t, hidden)
+
+	hidden, err = IsHidden(".")
+	assert.NoError(t, err)
+	assert.False(t, hidden)
+}
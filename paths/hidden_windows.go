@@ -0,0 +1,20 @@
+//go:build windows
+
+package paths
+
+import "syscall"
+
+// hasHiddenAttribute reports whether path has the FILE_ATTRIBUTE_HIDDEN attribute set.
+func hasHiddenAttribute(path string) (bool, error) {
+	p, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return false, err
+	}
+
+	attrs, err := syscall.GetFileAttributes(p)
+	if err != nil {
+		return false, err
+	}
+
+	return attrs&syscall.FILE_ATTRIBUTE_HIDDEN != 0, nil
+}
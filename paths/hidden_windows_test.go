@@ -0,0 +1,29 @@
+//go:build windows
+
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsHiddenAttribute(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret.txt")
+	assert.NoError(t, os.WriteFile(path, nil, 0644))
+
+	hidden, err := IsHidden(path)
+	assert.NoError(t, err)
+	assert.False(t, hidden)
+
+	p, err := syscall.UTF16PtrFromString(path)
+	assert.NoError(t, err)
+	assert.NoError(t, syscall.SetFileAttributes(p, syscall.FILE_ATTRIBUTE_HIDDEN))
+
+	hidden, err = IsHidden(path)
+	assert.NoError(t, err)
+	assert.True(t, hidden)
+}
@@ -0,0 +1,42 @@
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FindProjectRoot walks up from the working directory and returns the first directory containing marker,
+// such as "go.mod". It is FindRootByMarker for the single-marker case.
+func FindProjectRoot(marker string) (string, error) {
+	return FindRootByMarker(marker)
+}
+
+// FindRootByMarker walks up from the working directory and returns the first directory containing any of
+// markers, so FindRootByMarker("go.mod", ".git") stops at whichever appears first. Markers may be files or
+// directories. An error is returned if markers is empty or the filesystem root is reached without a match.
+func FindRootByMarker(markers ...string) (string, error) {
+	if len(markers) == 0 {
+		return "", errors.New("no root markers given")
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for dir := cwd; ; {
+		for _, marker := range markers {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return dir, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no directory containing any of %v found above %s", markers, cwd)
+		}
+		dir = parent
+	}
+}
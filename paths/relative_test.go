@@ -0,0 +1,43 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindRootByMarker(t *testing.T) {
+	root, err := FindRootByMarker("go.mod")
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(root, "go.mod"))
+
+	viaWrapper, err := FindProjectRoot("go.mod")
+	assert.NoError(t, err)
+	assert.Equal(t, root, viaWrapper)
+
+	_, err = FindRootByMarker()
+	assert.Error(t, err)
+}
+
+func TestFindRootByMarkerNearest(t *testing.T) {
+	base, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NoError(t, err)
+	nested := filepath.Join(base, "repo", "module", "pkg")
+	assert.NoError(t, os.MkdirAll(filepath.Join(base, "repo", ".git"), 0755))
+	assert.NoError(t, os.MkdirAll(nested, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(base, "repo", "module", "go.mod"), nil, 0644))
+	chdir(t, nested)
+
+	root, err := FindRootByMarker(".git", "go.mod")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(base, "repo", "module"), root)
+
+	root, err = FindRootByMarker(".git")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(base, "repo"), root)
+
+	_, err = FindRootByMarker("no-such-marker-go-util")
+	assert.ErrorContains(t, err, "no-such-marker-go-util")
+}
@@ -0,0 +1,16 @@
+package paths
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// IsSubPath reports whether path is base or lies beneath it, after both are cleaned.
+// Paths are compared lexically, so symlinks are not resolved.
+func IsSubPath(path, base string) bool {
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
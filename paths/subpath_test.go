@@ -0,0 +1,30 @@
+package paths
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSubPath(t *testing.T) {
+	base := filepath.FromSlash("/srv/app")
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/srv/app", true},
+		{"/srv/app/config/app.yaml", true},
+		{"/srv/app/../app/x", true},
+		{"/srv", false},
+		{"/srv/app/../other", false},
+		{"/srv/application", false},
+		{"/srv/app/..", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsSubPath(filepath.FromSlash(tt.path), base))
+		})
+	}
+}
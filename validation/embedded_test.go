@@ -0,0 +1,31 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Base struct {
+	BaseField string `yaml:"base_field"`
+}
+
+type ServiceConfig struct {
+	Base
+	Name string `yaml:"name"`
+}
+
+type DeploymentConfig struct {
+	Service ServiceConfig `yaml:"service"`
+}
+
+func TestValidateStructFieldsEmbedded(t *testing.T) {
+	_, err := ValidateStructFields(ServiceConfig{Name: "api"}, "")
+	assert.EqualError(t, err, "required fields are empty: [base_field]")
+
+	_, err = ValidateStructFields(DeploymentConfig{Service: ServiceConfig{Name: "api"}}, "")
+	assert.EqualError(t, err, "required fields are empty: [service.base_field]")
+
+	_, err = ValidateStructFields(ServiceConfig{Base: Base{BaseField: "x"}, Name: "api"}, "")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,35 @@
+package validation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mateothegreat/go-util/dates"
+)
+
+// ValidateDuration checks that value parses as a time.Duration such as "1h30m".
+func ValidateDuration(value string) error {
+	if _, err := time.ParseDuration(value); err != nil {
+		return fmt.Errorf("invalid duration %q: %w", value, err)
+	}
+	return nil
+}
+
+// ValidateTime checks that value parses as a time in the given layout.
+func ValidateTime(value string, layout dates.DateLayout) error {
+	if _, err := dates.Parse(layout, value); err != nil {
+		return fmt.Errorf("invalid time %q: %w", value, err)
+	}
+	return nil
+}
+
+// validateFormat checks value against a `format` tag ("duration" or "rfc3339").
+func validateFormat(format string, value string) error {
+	switch format {
+	case "duration":
+		return ValidateDuration(value)
+	case "rfc3339":
+		return ValidateTime(value, dates.DateLayoutRFC3339)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
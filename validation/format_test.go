@@ -0,0 +1,41 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/mateothegreat/go-util/dates"
+	"github.com/stretchr/testify/assert"
+)
+
+type ScheduleConfig struct {
+	Interval string `yaml:"interval" format:"duration"`
+	StartAt  string `yaml:"start_at" format:"rfc3339" required:"false"`
+}
+
+func TestValidateDuration(t *testing.T) {
+	assert.NoError(t, ValidateDuration("1h30m"))
+	assert.NoError(t, ValidateDuration("250ms"))
+	assert.Error(t, ValidateDuration("5 minutes"))
+}
+
+func TestValidateTime(t *testing.T) {
+	assert.NoError(t, ValidateTime("2024-08-07", dates.DateLayoutYYYYMMDD))
+	assert.Error(t, ValidateTime("08/07/2024", dates.DateLayoutYYYYMMDD))
+}
+
+func TestValidateStructFieldsFormat(t *testing.T) {
+	_, err := ValidateStructFields(ScheduleConfig{Interval: "5m", StartAt: "2024-08-07T12:00:00Z"}, "")
+	assert.NoError(t, err)
+
+	_, err = ValidateStructFields(ScheduleConfig{Interval: "5m"}, "")
+	assert.NoError(t, err, "empty optional values are skipped")
+
+	_, err = ValidateStructFields(ScheduleConfig{Interval: "five minutes"}, "")
+	assert.ErrorContains(t, err, "interval")
+
+	_, err = ValidateStructFields(ScheduleConfig{Interval: "5m", StartAt: "2024-08-07 12:00"}, "")
+	assert.ErrorContains(t, err, "start_at")
+
+	_, err = ValidateStructFields(ScheduleConfig{}, "")
+	assert.EqualError(t, err, "required fields are empty: [interval]")
+}
@@ -0,0 +1,53 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ValidateLen checks that the length n lies within the inclusive bounds [min, max].
+// A negative max leaves the upper end unbounded.
+func ValidateLen(n, min, max int) error {
+	if n < min || (max >= 0 && n > max) {
+		if max < 0 {
+			return fmt.Errorf("length %d is less than the minimum %d", n, min)
+		}
+		return fmt.Errorf("length %d is outside the range %d..%d", n, min, max)
+	}
+	return nil
+}
+
+// parseLen parses a `len` tag such as "1..5" into its inclusive bounds.
+// An omitted minimum ("..5") is 0 and an omitted maximum ("1..") is returned as -1 (unbounded).
+func parseLen(tag string) (int, int, error) {
+	lo, hi, ok := strings.Cut(tag, "..")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid len %q, expected min..max", tag)
+	}
+
+	min, max := 0, -1
+	var err error
+	if lo != "" {
+		if min, err = strconv.Atoi(lo); err != nil {
+			return 0, 0, fmt.Errorf("invalid len %q: %w", tag, err)
+		}
+	}
+	if hi != "" {
+		if max, err = strconv.Atoi(hi); err != nil {
+			return 0, 0, fmt.Errorf("invalid len %q: %w", tag, err)
+		}
+	}
+
+	return min, max, nil
+}
+
+// hasLen reports whether v is a slice, array or map, whose length can be constrained.
+func hasLen(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ClusterConfig struct {
+	Hosts  []string          `yaml:"hosts" len:"1..5" required:"false"`
+	Labels map[string]string `yaml:"labels" len:"1.." required:"false"`
+}
+
+func TestValidateLen(t *testing.T) {
+	assert.Error(t, ValidateLen(0, 1, 5))
+	assert.NoError(t, ValidateLen(1, 1, 5))
+	assert.NoError(t, ValidateLen(5, 1, 5))
+	assert.Error(t, ValidateLen(6, 1, 5))
+	assert.NoError(t, ValidateLen(100, 1, -1))
+}
+
+func TestValidateStructFieldsLen(t *testing.T) {
+	labels := map[string]string{"env": "prod"}
+	tests := []struct {
+		name    string
+		config  ClusterConfig
+		wantErr string
+	}{
+		{"under", ClusterConfig{Hosts: []string{}, Labels: labels}, "hosts"},
+		{"within", ClusterConfig{Hosts: []string{"a", "b"}, Labels: labels}, ""},
+		{"over", ClusterConfig{Hosts: []string{"a", "b", "c", "d", "e", "f"}, Labels: labels}, "hosts"},
+		{"min only", ClusterConfig{Hosts: []string{"a"}, Labels: map[string]string{}}, "labels"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateStructFields(tt.config, "")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseMinMax parses a `validate` tag such as "min=1,max=10" into its inclusive bounds.
+// Either rule may be omitted to leave that end unbounded.
+func parseMinMax(tag string) (float64, float64, error) {
+	min, max := math.Inf(-1), math.Inf(1)
+	for _, rule := range strings.Split(tag, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(rule), "=")
+		if !ok {
+			return 0, 0, fmt.Errorf("invalid validate rule %q, expected name=value", rule)
+		}
+
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid validate rule %q: %w", rule, err)
+		}
+
+		switch name {
+		case "min":
+			min = n
+		case "max":
+			max = n
+		default:
+			return 0, 0, fmt.Errorf("unknown validate rule %q", name)
+		}
+	}
+
+	return min, max, nil
+}
+
+// validateMinMax checks v against a `validate:"min=N,max=M"` tag. Numeric values are compared directly,
+// while strings (by rune count), slices, arrays and maps have their length compared.
+func validateMinMax(tag string, v reflect.Value) error {
+	min, max, err := parseMinMax(tag)
+	if err != nil {
+		return err
+	}
+
+	if n, ok := numericValue(v); ok {
+		switch {
+		case n < min:
+			return fmt.Errorf("value %v is less than the minimum %v", n, min)
+		case n > max:
+			return fmt.Errorf("value %v is greater than the maximum %v", n, max)
+		}
+		return nil
+	}
+
+	var length int
+	switch {
+	case v.Kind() == reflect.String:
+		length = utf8.RuneCountInString(v.String())
+	case hasLen(v):
+		length = v.Len()
+	default:
+		return nil
+	}
+
+	switch {
+	case float64(length) < min:
+		return fmt.Errorf("length %d is less than the minimum %v", length, min)
+	case float64(length) > max:
+		return fmt.Errorf("length %d is greater than the maximum %v", length, max)
+	}
+	return nil
+}
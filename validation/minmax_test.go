@@ -0,0 +1,91 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type NodeConfig struct {
+	Host    string `yaml:"host"`
+	Weight  int    `yaml:"weight" required:"false" validate:"min=1,max=10"`
+	Comment string `yaml:"comment" required:"false" validate:"max=8"`
+}
+
+type PoolConfig struct {
+	Name    string                `yaml:"name" validate:"min=3"`
+	Servers []NodeConfig          `yaml:"servers" validate:"min=1"`
+	Backups []*NodeConfig         `yaml:"backups" required:"false"`
+	Zones   map[string]NodeConfig `yaml:"zones" required:"false"`
+}
+
+func TestValidateStructFieldsMinMax(t *testing.T) {
+	_, err := ValidateStructFields(PoolConfig{Name: "main", Servers: []NodeConfig{{Host: "a", Weight: 5}}}, "")
+	assert.NoError(t, err)
+
+	_, err = ValidateStructFields(PoolConfig{Name: "ab", Servers: []NodeConfig{{Host: "a", Weight: 11, Comment: "far too long"}}}, "")
+	assert.EqualError(t, err, "invalid fields: ["+
+		"name: length 2 is less than the minimum 3 "+
+		"servers[0].weight: value 11 is greater than the maximum 10 "+
+		"servers[0].comment: length 12 is greater than the maximum 8]")
+}
+
+func TestValidateStructFieldsElements(t *testing.T) {
+	config := PoolConfig{
+		Name: "main",
+		Servers: []NodeConfig{
+			{Host: "a", Weight: 1},
+			{Host: "b", Weight: 1},
+			{Weight: 0},
+		},
+		Backups: []*NodeConfig{nil, {Weight: 1}},
+		Zones: map[string]NodeConfig{
+			"west": {Host: "w", Weight: 1},
+			"east": {Weight: 1},
+		},
+	}
+
+	_, err := ValidateStructFields(config, "")
+	assert.EqualError(t, err, "required fields are empty: [servers[2].host backups[1].host zones[east].host]; "+
+		"invalid fields: [servers[2].weight: value 0 is less than the minimum 1]")
+}
+
+func TestValidateStructFieldsElementsTrim(t *testing.T) {
+	config := &AccountList{Accounts: []ProfileConfig{{Name: " alice "}}}
+
+	_, err := ValidateStructFields(config, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", config.Accounts[0].Name)
+}
+
+type AccountList struct {
+	Accounts []ProfileConfig `yaml:"accounts"`
+}
+
+type QuotaConfig struct {
+	Replicas int     `yaml:"replicas" validate:"min=1,max=5"`
+	Ratio    float64 `yaml:"ratio" validate:"max=1"`
+}
+
+func TestValidateStructFieldsMinMaxRequiredNumeric(t *testing.T) {
+	_, err := ValidateStructFields(QuotaConfig{Replicas: 3, Ratio: 0.5}, "")
+	assert.NoError(t, err)
+
+	_, err = ValidateStructFields(QuotaConfig{Replicas: 9, Ratio: 0.5}, "")
+	assert.EqualError(t, err, "invalid fields: [replicas: value 9 is greater than the maximum 5]")
+
+	_, err = ValidateStructFields(QuotaConfig{Ratio: 0.5}, "")
+	assert.EqualError(t, err, "required fields are empty: [replicas]")
+}
+
+func TestParseMinMax(t *testing.T) {
+	min, max, err := parseMinMax("min=1, max=5")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, min)
+	assert.Equal(t, 5.0, max)
+
+	_, _, err = parseMinMax("min")
+	assert.Error(t, err)
+	_, _, err = parseMinMax("size=3")
+	assert.Error(t, err)
+}
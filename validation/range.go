@@ -0,0 +1,54 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ValidateRange checks that v lies within the inclusive bounds [min, max].
+func ValidateRange(v float64, min, max float64) error {
+	if v < min || v > max {
+		return fmt.Errorf("value %v is outside the range %v..%v", v, min, max)
+	}
+	return nil
+}
+
+// parseRange parses a `range` tag such as "1..100" into its inclusive bounds.
+// Either side may be omitted ("1.." or "..100") to leave that end unbounded.
+func parseRange(tag string) (float64, float64, error) {
+	lo, hi, ok := strings.Cut(tag, "..")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid range %q, expected min..max", tag)
+	}
+
+	min, max := math.Inf(-1), math.Inf(1)
+	var err error
+	if lo != "" {
+		if min, err = strconv.ParseFloat(lo, 64); err != nil {
+			return 0, 0, fmt.Errorf("invalid range %q: %w", tag, err)
+		}
+	}
+	if hi != "" {
+		if max, err = strconv.ParseFloat(hi, 64); err != nil {
+			return 0, 0, fmt.Errorf("invalid range %q: %w", tag, err)
+		}
+	}
+
+	return min, max, nil
+}
+
+// numericValue returns v as a float64 if it holds an int, uint or float kind.
+func numericValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
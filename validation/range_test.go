@@ -0,0 +1,43 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ServerConfig struct {
+	Port  int     `yaml:"port" range:"1..65535"`
+	Ratio float64 `yaml:"ratio" range:"0..1"`
+}
+
+func TestValidateRange(t *testing.T) {
+	assert.Error(t, ValidateRange(0, 1, 100))
+	assert.NoError(t, ValidateRange(1, 1, 100))
+	assert.NoError(t, ValidateRange(50, 1, 100))
+	assert.NoError(t, ValidateRange(100, 1, 100))
+	assert.Error(t, ValidateRange(101, 1, 100))
+}
+
+func TestValidateStructFieldsRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ServerConfig
+		wantErr bool
+	}{
+		{"missing", ServerConfig{Ratio: 0.5}, true},
+		{"below", ServerConfig{Port: -1, Ratio: 0.5}, true},
+		{"within", ServerConfig{Port: 8080, Ratio: 0.5}, false},
+		{"above", ServerConfig{Port: 70000, Ratio: 1.5}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateStructFields(tt.config, "")
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "port")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,15 +1,15 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 func ValidateStructFields(v interface{}, path string) ([]string, error) {
-	var emptyFields []string
-	var requiredErrors []string
-
 	val := reflect.ValueOf(v)
 	if val.Kind() == reflect.Ptr {
 		val = val.Elem()
@@ -18,6 +18,33 @@ func ValidateStructFields(v interface{}, path string) ([]string, error) {
 		return nil, fmt.Errorf("CheckStructFields expects a struct, got %s", val.Kind())
 	}
 
+	var r structReport
+	validateStruct(val, path, &r)
+
+	var problems []string
+	if len(r.required) > 0 {
+		problems = append(problems, fmt.Sprintf("required fields are empty: %v", r.required))
+	}
+	if len(r.violations) > 0 {
+		problems = append(problems, fmt.Sprintf("invalid fields: %v", r.violations))
+	}
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+
+	return r.empty, nil
+}
+
+// structReport accumulates the results of validating a struct and everything nested in it.
+type structReport struct {
+	empty      []string
+	required   []string
+	violations []string
+}
+
+// validateStruct validates the fields of the struct val, recursing into nested structs and into
+// the struct elements of slices, arrays and maps. Field paths are prefixed with path.
+func validateStruct(val reflect.Value, path string, r *structReport) {
 	t := val.Type()
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
@@ -26,23 +53,79 @@ func ValidateStructFields(v interface{}, path string) ([]string, error) {
 		requiredTag := field.Tag.Get("required")
 		fieldPath := path + yamlTag
 
+		if field.Tag.Get("trim") == "true" && fieldValue.Kind() == reflect.String {
+			fieldValue = trimString(fieldValue)
+		}
+
 		if field.Type.Kind() == reflect.Struct && (requiredTag == "" || requiredTag == "true") {
-			nestedEmpty, err := ValidateStructFields(fieldValue.Interface(), fieldPath+".")
-			if err != nil {
-				return nil, err
+			nestedPath := fieldPath + "."
+			if field.Anonymous {
+				// Promote embedded struct fields to the parent path.
+				nestedPath = path
 			}
-			emptyFields = append(emptyFields, nestedEmpty...)
+			// fieldValue stays addressable when a pointer was passed, so nested write-backs such as trimming work.
+			validateStruct(fieldValue, nestedPath, r)
 		} else if IsStructFieldEmpty(fieldValue) && (requiredTag == "" || requiredTag == "true") {
-			emptyFields = append(emptyFields, fieldPath)
-			requiredErrors = append(requiredErrors, fieldPath)
+			r.empty = append(r.empty, fieldPath)
+			r.required = append(r.required, fieldPath)
+		} else {
+			r.violations = append(r.violations, validateTags(field, fieldValue, fieldPath)...)
+			validateElements(fieldValue, fieldPath, r)
 		}
 	}
+}
 
-	if len(requiredErrors) > 0 {
-		return nil, fmt.Errorf("required fields are empty: %v", requiredErrors)
+// validateElements validates each struct (or non-nil pointer to struct) element of the slice, array or map v,
+// reporting nested fields as path[index].field or path[key].field.
+func validateElements(v reflect.Value, path string, r *structReport) {
+	if !hasLen(v) {
+		return
 	}
 
-	return emptyFields, nil
+	elem := v.Type().Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return
+	}
+
+	visit := func(item reflect.Value, index string) {
+		if item.Kind() == reflect.Ptr {
+			if item.IsNil() {
+				return
+			}
+			item = item.Elem()
+		}
+		validateStruct(item, fmt.Sprintf("%s[%s].", path, index), r)
+	}
+
+	if v.Kind() == reflect.Map {
+		keys := v.MapKeys()
+		// Sort keys so the reported violations are stable.
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			visit(v.MapIndex(key), fmt.Sprint(key.Interface()))
+		}
+		return
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		visit(v.Index(i), strconv.Itoa(i))
+	}
+}
+
+// trimString trims leading and trailing whitespace from the string value v.
+// The trimmed value is written back when v is settable, i.e. when a pointer was passed to ValidateStructFields.
+func trimString(v reflect.Value) reflect.Value {
+	trimmed := strings.TrimSpace(v.String())
+	if v.CanSet() {
+		v.SetString(trimmed)
+		return v
+	}
+	return reflect.ValueOf(trimmed).Convert(v.Type())
 }
 
 func IsStructFieldEmpty(v reflect.Value) bool {
@@ -52,14 +135,11 @@ func IsStructFieldEmpty(v reflect.Value) bool {
 	case reflect.Bool:
 		return !v.Bool()
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		_, err := strconv.ParseInt(v.String(), 10, 64)
-		return err != nil
+		return v.Int() == 0
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		_, err := strconv.ParseUint(v.String(), 10, 64)
-		return err != nil
+		return v.Uint() == 0
 	case reflect.Float32, reflect.Float64:
-		_, err := strconv.ParseFloat(v.String(), 64)
-		return err != nil
+		return v.Float() == 0
 	case reflect.Interface, reflect.Ptr:
 		return v.IsNil()
 	case reflect.Array, reflect.Slice, reflect.Map:
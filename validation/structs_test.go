@@ -18,8 +18,9 @@ func TestIsZero(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(fmt.Sprintf("%v", tt), func(t *testing.T) {
-			if ok := IsStructFieldEmpty(reflect.ValueOf(tt.foo)); !ok {
-				t.Errorf("IsStructFieldEmpty() = %v, want %v", ok, tt.foo)
+			want := tt.foo == 0
+			if ok := IsStructFieldEmpty(reflect.ValueOf(tt.foo)); ok != want {
+				t.Errorf("IsStructFieldEmpty(%v) = %v, want %v", tt.foo, ok, want)
 			}
 		})
 	}
@@ -0,0 +1,54 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// validateTags runs the tag-based checks (such as `url`, `range`, `len`, `format` and `validate`) for a single struct field
+// and returns a description of each violation prefixed with fieldPath.
+func validateTags(field reflect.StructField, v reflect.Value, fieldPath string) []string {
+	var violations []string
+
+	if tag := field.Tag.Get("url"); tag != "" && v.Kind() == reflect.String && v.Len() > 0 {
+		if err := ValidateURL(v.String(), urlSchemes(tag)...); err != nil {
+			violations = append(violations, fmt.Sprintf("%s: %v", fieldPath, err))
+		}
+	}
+
+	if tag := field.Tag.Get("range"); tag != "" {
+		if n, ok := numericValue(v); ok {
+			min, max, err := parseRange(tag)
+			if err == nil {
+				err = ValidateRange(n, min, max)
+			}
+			if err != nil {
+				violations = append(violations, fmt.Sprintf("%s: %v", fieldPath, err))
+			}
+		}
+	}
+
+	if tag := field.Tag.Get("len"); tag != "" && hasLen(v) {
+		min, max, err := parseLen(tag)
+		if err == nil {
+			err = ValidateLen(v.Len(), min, max)
+		}
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("%s: %v", fieldPath, err))
+		}
+	}
+
+	if tag := field.Tag.Get("format"); tag != "" && v.Kind() == reflect.String && v.Len() > 0 {
+		if err := validateFormat(tag, v.String()); err != nil {
+			violations = append(violations, fmt.Sprintf("%s: %v", fieldPath, err))
+		}
+	}
+
+	if tag := field.Tag.Get("validate"); tag != "" {
+		if err := validateMinMax(tag, v); err != nil {
+			violations = append(violations, fmt.Sprintf("%s: %v", fieldPath, err))
+		}
+	}
+
+	return violations
+}
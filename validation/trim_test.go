@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ProfileConfig struct {
+	Name string `yaml:"name" trim:"true"`
+}
+
+type AccountConfig struct {
+	Owner   string        `yaml:"owner" trim:"true"`
+	Profile ProfileConfig `yaml:"profile"`
+}
+
+func TestValidateStructFieldsTrim(t *testing.T) {
+	_, err := ValidateStructFields(AccountConfig{Owner: "   ", Profile: ProfileConfig{Name: "x"}}, "")
+	assert.ErrorContains(t, err, "owner")
+
+	_, err = ValidateStructFields(AccountConfig{Owner: "alice", Profile: ProfileConfig{Name: "\t\n"}}, "")
+	assert.ErrorContains(t, err, "profile.name")
+}
+
+func TestValidateStructFieldsTrimWriteBack(t *testing.T) {
+	config := &AccountConfig{Owner: "  alice ", Profile: ProfileConfig{Name: " main\n"}}
+
+	_, err := ValidateStructFields(config, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", config.Owner)
+	assert.Equal(t, "main", config.Profile.Name)
+
+	value := AccountConfig{Owner: "  bob ", Profile: ProfileConfig{Name: "x"}}
+	_, err = ValidateStructFields(value, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "  bob ", value.Owner)
+}
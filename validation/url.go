@@ -0,0 +1,42 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ValidateURL checks that value parses as a URL.
+// When allowedSchemes is non-empty the URL must also be absolute and use one of those schemes,
+// so relative URLs are rejected.
+func ValidateURL(value string, allowedSchemes ...string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("invalid url %q: %w", value, err)
+	}
+
+	if len(allowedSchemes) == 0 {
+		return nil
+	}
+
+	if !u.IsAbs() || u.Host == "" {
+		return fmt.Errorf("url %q must be absolute with scheme %s", value, strings.Join(allowedSchemes, " or "))
+	}
+
+	for _, scheme := range allowedSchemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("url %q has scheme %q, expected %s", value, u.Scheme, strings.Join(allowedSchemes, " or "))
+}
+
+// urlSchemes returns the schemes listed in a `url` tag such as "https" or "http,https".
+// The value "true" allows any scheme.
+func urlSchemes(tag string) []string {
+	if tag == "true" {
+		return nil
+	}
+	return strings.Split(tag, ",")
+}
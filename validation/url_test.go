@@ -0,0 +1,28 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type EndpointConfig struct {
+	Endpoint string `yaml:"endpoint" url:"https"`
+}
+
+func TestValidateURL(t *testing.T) {
+	assert.NoError(t, ValidateURL("https://example.com/api", "https"))
+	assert.NoError(t, ValidateURL("/relative/path"))
+	assert.Error(t, ValidateURL("http://example.com", "https"))
+	assert.Error(t, ValidateURL("/relative/path", "https"))
+	assert.Error(t, ValidateURL("https://exa mple.com/%zz", "https"))
+	assert.Error(t, ValidateURL("://missing-scheme"))
+}
+
+func TestValidateStructFieldsURL(t *testing.T) {
+	_, err := ValidateStructFields(EndpointConfig{Endpoint: "https://example.com"}, "")
+	assert.NoError(t, err)
+
+	_, err = ValidateStructFields(EndpointConfig{Endpoint: "http://example.com"}, "")
+	assert.ErrorContains(t, err, "endpoint")
+}
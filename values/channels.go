@@ -0,0 +1,40 @@
+package values
+
+import "context"
+
+// SliceToChannel returns a channel that yields each element of s in order and is then closed.
+// The channel is buffered to len(s), so no goroutine is left behind if the caller stops reading early.
+func SliceToChannel[T any](s []T) <-chan T {
+	ch := make(chan T, len(s))
+	for _, v := range s {
+		ch <- v
+	}
+	close(ch)
+	return ch
+}
+
+// ChannelToSlice drains ch into a slice, returning once ch is closed.
+func ChannelToSlice[T any](ch <-chan T) []T {
+	out := []T{}
+	for v := range ch {
+		out = append(out, v)
+	}
+	return out
+}
+
+// DrainWithContext drains ch into a slice until ch is closed or ctx is cancelled.
+// On cancellation it returns the elements received so far along with ctx.Err().
+func DrainWithContext[T any](ctx context.Context, ch <-chan T) ([]T, error) {
+	out := []T{}
+	for {
+		select {
+		case <-ctx.Done():
+			return out, ctx.Err()
+		case v, ok := <-ch:
+			if !ok {
+				return out, nil
+			}
+			out = append(out, v)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package values
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceToChannel(t *testing.T) {
+	var got []int
+	for v := range SliceToChannel([]int{1, 2, 3}) {
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	_, ok := <-SliceToChannel([]int{})
+	assert.False(t, ok)
+}
+
+func TestChannelToSlice(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, ChannelToSlice(SliceToChannel([]string{"a", "b"})))
+
+	empty := make(chan string)
+	close(empty)
+	assert.Equal(t, []string{}, ChannelToSlice(empty))
+}
+
+func TestDrainWithContext(t *testing.T) {
+	got, err := DrainWithContext(context.Background(), SliceToChannel([]int{1, 2}))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, got)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	open := make(chan int)
+	go func() {
+		open <- 1
+		cancel()
+	}()
+
+	got, err = DrainWithContext(ctx, open)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []int{1}, got)
+}
@@ -0,0 +1,83 @@
+package values
+
+// Apply replaces each value in m with the result of fn, mutating m in place.
+// Use MappedCopy to leave m untouched.
+func Apply[K comparable, V any](m map[K]V, fn func(K, V) V) {
+	for k, v := range m {
+		m[k] = fn(k, v)
+	}
+}
+
+// MappedCopy returns a new map with each value of m replaced by the result of fn.
+// Unlike Apply, m is not modified.
+func MappedCopy[K comparable, V any](m map[K]V, fn func(K, V) V) map[K]V {
+	out := make(map[K]V, len(m))
+	for k, v := range m {
+		out[k] = fn(k, v)
+	}
+	return out
+}
+
+// SafeGet descends through nested map[string]interface{} values (as produced by decoding JSON)
+// following keys, and returns the value at the end of the path and whether the full path existed.
+// It never panics on missing keys or on intermediate values that are not maps.
+func SafeGet(m map[string]interface{}, keys ...string) (interface{}, bool) {
+	var current interface{} = m
+	for _, key := range keys {
+		next, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = next[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// GetString returns the string at the nested keys path in m, or def if the path is missing or not a string.
+func GetString(m map[string]interface{}, def string, keys ...string) string {
+	v, ok := SafeGet(m, keys...)
+	if !ok {
+		return def
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return def
+}
+
+// GetInt returns the integer at the nested keys path in m, or def if the path is missing or not a whole number.
+// Both int values and the float64 values produced by encoding/json are accepted.
+func GetInt(m map[string]interface{}, def int, keys ...string) int {
+	v, ok := SafeGet(m, keys...)
+	if !ok {
+		return def
+	}
+	switch n := v.(type) {
+	case int:
+		return n
+	case int64:
+		return int(n)
+	case float64:
+		if n == float64(int(n)) {
+			return int(n)
+		}
+	}
+	return def
+}
+
+// ZipMap builds a map pairing keys[i] with values[i].
+// Extra elements of the longer slice are ignored, and when a key repeats the later value overwrites the earlier one.
+func ZipMap[K comparable, V any](keys []K, values []V) map[K]V {
+	n := len(keys)
+	if len(values) < n {
+		n = len(values)
+	}
+
+	out := make(map[K]V, n)
+	for i := 0; i < n; i++ {
+		out[keys[i]] = values[i]
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package values
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApply(t *testing.T) {
+	m := map[string]string{"Host": " Example.COM ", "path": "/API"}
+	Apply(m, func(k, v string) string { return strings.ToLower(strings.TrimSpace(v)) })
+	assert.Equal(t, map[string]string{"Host": "example.com", "path": "/api"}, m)
+}
+
+func TestMappedCopy(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	doubled := MappedCopy(m, func(k string, v int) int { return v * 2 })
+
+	assert.Equal(t, map[string]int{"a": 2, "b": 4}, doubled)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m)
+}
+
+func TestSafeGet(t *testing.T) {
+	var doc map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"server":{"tls":{"cert":"x.pem","port":8443,"ratio":0.5}},"name":"app"}`), &doc))
+
+	v, ok := SafeGet(doc, "server", "tls", "cert")
+	assert.True(t, ok)
+	assert.Equal(t, "x.pem", v)
+
+	_, ok = SafeGet(doc, "server", "missing", "cert")
+	assert.False(t, ok)
+
+	_, ok = SafeGet(doc, "name", "nested")
+	assert.False(t, ok, "descending into a non-map value")
+
+	v, ok = SafeGet(doc)
+	assert.True(t, ok)
+	assert.Equal(t, doc, v)
+
+	assert.Equal(t, "x.pem", GetString(doc, "default", "server", "tls", "cert"))
+	assert.Equal(t, "default", GetString(doc, "default", "server", "tls", "port"))
+	assert.Equal(t, 8443, GetInt(doc, -1, "server", "tls", "port"))
+	assert.Equal(t, -1, GetInt(doc, -1, "server", "tls", "ratio"))
+	assert.Equal(t, -1, GetInt(doc, -1, "name"))
+}
+
+func TestZipMap(t *testing.T) {
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, ZipMap([]string{"a", "b"}, []int{1, 2}))
+	assert.Equal(t, map[string]int{"a": 1}, ZipMap([]string{"a", "b", "c"}, []int{1}))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, ZipMap([]string{"a", "b"}, []int{1, 2, 3}))
+	assert.Equal(t, map[string]int{"a": 3, "b": 2}, ZipMap([]string{"a", "b", "a"}, []int{1, 2, 3}))
+	assert.Empty(t, ZipMap([]string{}, []int{1}))
+}
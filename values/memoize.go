@@ -0,0 +1,68 @@
+package values
+
+import "sync"
+
+// Memoize returns a function that caches the results of fn by argument.
+// The returned function is safe for concurrent use, and fn is invoked only once per distinct argument:
+// concurrent first calls for the same argument wait for the one in flight instead of calling fn again.
+func Memoize[K comparable, V any](fn func(K) V) func(K) V {
+	type entry struct {
+		once sync.Once
+		v    V
+	}
+
+	var mu sync.Mutex
+	cache := make(map[K]*entry)
+
+	return func(k K) V {
+		mu.Lock()
+		e, ok := cache[k]
+		if !ok {
+			e = &entry{}
+			cache[k] = e
+		}
+		mu.Unlock()
+
+		e.once.Do(func() { e.v = fn(k) })
+		return e.v
+	}
+}
+
+// MemoizeErr is like Memoize for functions that can fail.
+// Only successful results are cached, so a call that returned an error is retried next time.
+// Concurrent calls for an argument that is already in flight wait for it and share its result, including an error.
+func MemoizeErr[K comparable, V any](fn func(K) (V, error)) func(K) (V, error) {
+	type call struct {
+		done chan struct{}
+		v    V
+		err  error
+	}
+
+	var mu sync.Mutex
+	cache := make(map[K]*call)
+
+	return func(k K) (V, error) {
+		mu.Lock()
+		if c, ok := cache[k]; ok {
+			mu.Unlock()
+			<-c.done
+			return c.v, c.err
+		}
+		c := &call{done: make(chan struct{})}
+		cache[k] = c
+		mu.Unlock()
+
+		func() {
+			defer close(c.done)
+			c.v, c.err = fn(k)
+			if c.err != nil {
+				// Forget the failure so the next call retries.
+				mu.Lock()
+				delete(cache, k)
+				mu.Unlock()
+			}
+		}()
+
+		return c.v, c.err
+	}
+}
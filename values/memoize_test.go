@@ -0,0 +1,87 @@
+package values
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoize(t *testing.T) {
+	calls := map[int]int{}
+	square := Memoize(func(n int) int {
+		calls[n]++
+		return n * n
+	})
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 4, square(2))
+		assert.Equal(t, 9, square(3))
+	}
+	assert.Equal(t, map[int]int{2: 1, 3: 1}, calls)
+}
+
+func TestMemoizeErr(t *testing.T) {
+	calls := 0
+	fail := true
+	lookup := MemoizeErr(func(key string) (string, error) {
+		calls++
+		if fail {
+			return "", errors.New("unavailable")
+		}
+		return "value:" + key, nil
+	})
+
+	_, err := lookup("a")
+	assert.Error(t, err)
+
+	fail = false
+	v, err := lookup("a")
+	assert.NoError(t, err)
+	assert.Equal(t, "value:a", v)
+
+	v, err = lookup("a")
+	assert.NoError(t, err)
+	assert.Equal(t, "value:a", v)
+	assert.Equal(t, 2, calls)
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	slow := Memoize(func(n int) int {
+		calls.Add(1)
+		<-release
+		return n * 2
+	})
+	slowErr := MemoizeErr(func(n int) (int, error) {
+		calls.Add(1)
+		<-release
+		return n * 2, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, 42, slow(21))
+		}()
+		go func() {
+			defer wg.Done()
+			v, err := slowErr(21)
+			assert.NoError(t, err)
+			assert.Equal(t, 42, v)
+		}()
+	}
+
+	// Let the goroutines pile up on the in-flight calls before releasing them.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,100 @@
+package values
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseInt parses s as a base 10 int, returning def if s is not a valid int.
+func ParseInt(s string, def int) int {
+	v, err := TryParse[int](s)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// ParseFloat parses s as a float64, returning def if s is not a valid float.
+func ParseFloat(s string, def float64) float64 {
+	v, err := TryParse[float64](s)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// ParseBool parses s as a bool (as accepted by strconv.ParseBool), returning def if s is not a valid bool.
+func ParseBool(s string, def bool) bool {
+	v, err := TryParse[bool](s)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// TryParse converts s to T for the basic scalar types: string, bool, the int and uint types, float32 and float64.
+// Surrounding whitespace is ignored. An error is returned when s is not valid for T or T is not supported.
+func TryParse[T any](s string) (T, error) {
+	var zero T
+	s = strings.TrimSpace(s)
+
+	var v any
+	var err error
+	switch any(zero).(type) {
+	case string:
+		v = s
+	case bool:
+		v, err = strconv.ParseBool(s)
+	case int:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, 0)
+		v = int(n)
+	case int8:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, 8)
+		v = int8(n)
+	case int16:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, 16)
+		v = int16(n)
+	case int32:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, 32)
+		v = int32(n)
+	case int64:
+		v, err = strconv.ParseInt(s, 10, 64)
+	case uint:
+		var n uint64
+		n, err = strconv.ParseUint(s, 10, 0)
+		v = uint(n)
+	case uint8:
+		var n uint64
+		n, err = strconv.ParseUint(s, 10, 8)
+		v = uint8(n)
+	case uint16:
+		var n uint64
+		n, err = strconv.ParseUint(s, 10, 16)
+		v = uint16(n)
+	case uint32:
+		var n uint64
+		n, err = strconv.ParseUint(s, 10, 32)
+		v = uint32(n)
+	case uint64:
+		v, err = strconv.ParseUint(s, 10, 64)
+	case float32:
+		var f float64
+		f, err = strconv.ParseFloat(s, 32)
+		v = float32(f)
+	case float64:
+		v, err = strconv.ParseFloat(s, 64)
+	default:
+		return zero, fmt.Errorf("unsupported type %T", zero)
+	}
+
+	if err != nil {
+		return zero, err
+	}
+
+	return v.(T), nil
+}
@@ -0,0 +1,56 @@
+package values
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInt(t *testing.T) {
+	assert.Equal(t, 42, ParseInt("42", 7))
+	assert.Equal(t, -3, ParseInt(" -3 ", 7))
+	assert.Equal(t, 7, ParseInt("4.2", 7))
+	assert.Equal(t, 7, ParseInt("", 7))
+}
+
+func TestParseFloat(t *testing.T) {
+	assert.Equal(t, 4.25, ParseFloat("4.25", 1))
+	assert.Equal(t, 1.0, ParseFloat("four", 1))
+}
+
+func TestParseBool(t *testing.T) {
+	assert.True(t, ParseBool("true", false))
+	assert.False(t, ParseBool("0", true))
+	assert.True(t, ParseBool("maybe", true))
+}
+
+func TestTryParse(t *testing.T) {
+	i, err := TryParse[int]("12")
+	assert.NoError(t, err)
+	assert.Equal(t, 12, i)
+
+	u8, err := TryParse[uint8]("255")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(255), u8)
+
+	_, err = TryParse[uint8]("256")
+	assert.Error(t, err)
+
+	f, err := TryParse[float32]("1.5")
+	assert.NoError(t, err)
+	assert.Equal(t, float32(1.5), f)
+
+	b, err := TryParse[bool]("TRUE")
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	_, err = TryParse[bool]("yes")
+	assert.Error(t, err)
+
+	s, err := TryParse[string](" value ")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", s)
+
+	_, err = TryParse[[]byte]("x")
+	assert.ErrorContains(t, err, "unsupported type")
+}
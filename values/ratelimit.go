@@ -0,0 +1,46 @@
+package values
+
+import (
+	"sync"
+	"time"
+)
+
+// throttleNow returns the current time for Throttle. Tests replace it to control the clock.
+var throttleNow = time.Now
+
+// Debounce returns a function that delays calling fn until d has elapsed since the last call (trailing edge).
+// A burst of calls results in a single invocation of fn. The returned function is safe for concurrent use.
+func Debounce(d time.Duration, fn func()) func() {
+	var mu sync.Mutex
+	var timer *time.Timer
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(d, fn)
+	}
+}
+
+// Throttle returns a function that calls fn at most once per d (leading edge).
+// Calls made within d of the last invocation are dropped. The returned function is safe for concurrent use.
+func Throttle(d time.Duration, fn func()) func() {
+	var mu sync.Mutex
+	var last time.Time
+
+	return func() {
+		mu.Lock()
+		now := throttleNow()
+		if !last.IsZero() && now.Sub(last) < d {
+			mu.Unlock()
+			return
+		}
+		last = now
+		mu.Unlock()
+
+		fn()
+	}
+}
@@ -0,0 +1,73 @@
+package values
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebounce(t *testing.T) {
+	// The delay is long enough that the burst always completes before fn can fire, even on a loaded machine.
+	const delay = time.Second
+
+	var calls int32
+	debounced := Debounce(delay, func() { atomic.AddInt32(&calls, 1) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			debounced()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, 5*delay, 10*time.Millisecond)
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestThrottle(t *testing.T) {
+	var mu sync.Mutex
+	clock := time.Date(2024, 8, 7, 0, 0, 0, 0, time.UTC)
+	throttleNow = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return clock
+	}
+	defer func() { throttleNow = time.Now }()
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		clock = clock.Add(d)
+	}
+
+	var calls int32
+	throttled := Throttle(50*time.Millisecond, func() { atomic.AddInt32(&calls, 1) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			throttled()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	advance(49 * time.Millisecond)
+	throttled()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	advance(time.Millisecond)
+	throttled()
+	throttled()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
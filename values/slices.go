@@ -0,0 +1,137 @@
+package values
+
+import "fmt"
+
+// Count returns the number of times each distinct element appears in s.
+func Count[T comparable](s []T) map[T]int {
+	counts := make(map[T]int)
+	for _, v := range s {
+		counts[v]++
+	}
+	return counts
+}
+
+// CountBy returns the number of elements in s for each key derived by key.
+func CountBy[T any, K comparable](s []T, key func(T) K) map[K]int {
+	counts := make(map[K]int)
+	for _, v := range s {
+		counts[key(v)]++
+	}
+	return counts
+}
+
+// GroupConsecutive splits s into runs of adjacent elements that share the same key.
+// Unlike a global grouping, equal keys that are not adjacent end up in separate runs.
+func GroupConsecutive[T any, K comparable](s []T, key func(T) K) [][]T {
+	var groups [][]T
+	var lastKey K
+	for i, v := range s {
+		k := key(v)
+		if i == 0 || k != lastKey {
+			groups = append(groups, []T{})
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], v)
+		lastKey = k
+	}
+	return groups
+}
+
+// Batch calls fn with consecutive sub-slices of s holding up to size elements each.
+// It stops and returns the first error returned by fn. The batches share s's backing array.
+func Batch[T any](s []T, size int, fn func(batch []T) error) error {
+	if size <= 0 {
+		return fmt.Errorf("invalid batch size %d", size)
+	}
+
+	for start := 0; start < len(s); start += size {
+		end := start + size
+		if end > len(s) {
+			end = len(s)
+		}
+		if err := fn(s[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Map returns a slice holding the result of fn for each element of s.
+func Map[T, U any](s []T, fn func(T) U) []U {
+	out := make([]U, len(s))
+	for i, v := range s {
+		out[i] = fn(v)
+	}
+	return out
+}
+
+// Filter returns the elements of s for which fn returns true, in their original order.
+// The result is never nil, so it can be ranged over or encoded as an empty list when nothing matches.
+func Filter[T any](s []T, fn func(T) bool) []T {
+	out := make([]T, 0)
+	for _, v := range s {
+		if fn(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Reduce folds s from left to right into init using fn and returns the final accumulator.
+func Reduce[T, U any](s []T, init U, fn func(acc U, v T) U) U {
+	acc := init
+	for _, v := range s {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// IndexedMap returns a slice holding the result of fn for each element of s along with its index.
+func IndexedMap[T, U any](s []T, fn func(i int, v T) U) []U {
+	out := make([]U, len(s))
+	for i, v := range s {
+		out[i] = fn(i, v)
+	}
+	return out
+}
+
+// EachIndexed calls fn for each element of s along with its index.
+func EachIndexed[T any](s []T, fn func(i int, v T)) {
+	for i, v := range s {
+		fn(i, v)
+	}
+}
+
+// ReduceWhile folds s from left to right into init using fn, stopping as soon as fn returns false.
+// The accumulator returned alongside false is kept, so the result is the partial accumulation up to that point.
+func ReduceWhile[T, U any](s []T, init U, fn func(acc U, v T) (U, bool)) U {
+	acc := init
+	for _, v := range s {
+		var more bool
+		acc, more = fn(acc, v)
+		if !more {
+			break
+		}
+	}
+	return acc
+}
+
+// All reports whether pred holds for every element of s. It is vacuously true for an empty slice.
+func All[T any](s []T, pred func(T) bool) bool {
+	for _, v := range s {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Any reports whether pred holds for at least one element of s. It is false for an empty slice.
+func Any[T any](s []T, pred func(T) bool) bool {
+	for _, v := range s {
+		if pred(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package values
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCount(t *testing.T) {
+	counts := Count([]string{"a", "b", "a", "c", "a", "b"})
+	assert.Equal(t, map[string]int{"a": 3, "b": 2, "c": 1}, counts)
+	assert.Empty(t, Count([]int{}))
+}
+
+func TestCountBy(t *testing.T) {
+	counts := CountBy([]string{"Go", "go", "Rust", "GO"}, strings.ToLower)
+	assert.Equal(t, map[string]int{"go": 3, "rust": 1}, counts)
+}
+
+func TestGroupConsecutive(t *testing.T) {
+	identity := func(v int) int { return v }
+
+	assert.Equal(t, [][]int{{1, 1}, {2, 2, 2}, {3}}, GroupConsecutive([]int{1, 1, 2, 2, 2, 3}, identity))
+	assert.Equal(t, [][]int{{1}, {2}, {1}, {2}}, GroupConsecutive([]int{1, 2, 1, 2}, identity))
+	assert.Nil(t, GroupConsecutive([]int{}, identity))
+
+	parity := GroupConsecutive([]int{2, 4, 1, 3, 6}, func(v int) bool { return v%2 == 0 })
+	assert.Equal(t, [][]int{{2, 4}, {1, 3}, {6}}, parity)
+}
+
+func TestBatch(t *testing.T) {
+	var sizes []int
+	err := Batch([]int{1, 2, 3, 4, 5, 6, 7}, 3, func(batch []int) error {
+		sizes = append(sizes, len(batch))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 3, 1}, sizes)
+
+	stop := errors.New("stop")
+	var seen [][]int
+	err = Batch([]int{1, 2, 3, 4, 5}, 2, func(batch []int) error {
+		seen = append(seen, batch)
+		if len(seen) == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, seen)
+
+	assert.Error(t, Batch([]int{1}, 0, func([]int) error { return nil }))
+}
+
+func TestMap(t *testing.T) {
+	assert.Equal(t, []string{"1", "2", "3"}, Map([]int{1, 2, 3}, strconv.Itoa))
+	assert.Equal(t, []string{}, Map([]int(nil), strconv.Itoa))
+}
+
+func TestFilter(t *testing.T) {
+	assert.Equal(t, []int{2, 4}, Filter([]int{1, 2, 3, 4}, func(v int) bool { return v%2 == 0 }))
+	assert.Equal(t, []string{"a", "b"}, Filter([]string{"", "a", "", "b"}, func(v string) bool { return !IsZero(v) }))
+
+	none := Filter([]int{1, 3}, func(v int) bool { return v%2 == 0 })
+	assert.NotNil(t, none)
+	assert.Empty(t, none)
+	assert.NotNil(t, Filter([]int(nil), func(int) bool { return true }))
+}
+
+func TestReduce(t *testing.T) {
+	assert.Equal(t, 10, Reduce([]int{1, 2, 3, 4}, 0, func(acc, v int) int { return acc + v }))
+	assert.Equal(t, "abc", Reduce([]string{"a", "b", "c"}, "", func(acc, v string) string { return acc + v }))
+	assert.Equal(t, 7, Reduce([]int(nil), 7, func(acc, v int) int { return acc + v }))
+}
+
+func TestIndexedMap(t *testing.T) {
+	got := IndexedMap([]string{"a", "b", "c"}, func(i int, v string) string {
+		return fmt.Sprintf("%d:%s", i, v)
+	})
+	assert.Equal(t, []string{"0:a", "1:b", "2:c"}, got)
+	assert.Empty(t, IndexedMap([]int{}, func(i int, v int) int { return i }))
+}
+
+func TestEachIndexed(t *testing.T) {
+	var indexes []int
+	var values []string
+	EachIndexed([]string{"x", "y"}, func(i int, v string) {
+		indexes = append(indexes, i)
+		values = append(values, v)
+	})
+	assert.Equal(t, []int{0, 1}, indexes)
+	assert.Equal(t, []string{"x", "y"}, values)
+}
+
+func TestReduceWhile(t *testing.T) {
+	visited := 0
+	sum := ReduceWhile([]int{4, 3, 5, 2, 8}, 0, func(acc int, v int) (int, bool) {
+		visited++
+		acc += v
+		return acc, acc < 10
+	})
+	assert.Equal(t, 12, sum)
+	assert.Equal(t, 3, visited)
+
+	all := ReduceWhile([]int{1, 2, 3}, 0, func(acc int, v int) (int, bool) { return acc + v, true })
+	assert.Equal(t, 6, all)
+
+	assert.Equal(t, "init", ReduceWhile([]int{}, "init", func(acc string, v int) (string, bool) { return "", true }))
+}
+
+func TestAllAndAny(t *testing.T) {
+	positive := func(v int) bool { return v > 0 }
+
+	assert.True(t, All([]int{1, 2, 3}, positive))
+	assert.False(t, All([]int{1, -2, 3}, positive))
+	assert.True(t, All([]int{}, positive))
+
+	assert.True(t, Any([]int{-1, 2, -3}, positive))
+	assert.False(t, Any([]int{-1, -2}, positive))
+	assert.False(t, Any([]int{}, positive))
+}
@@ -0,0 +1,45 @@
+package values
+
+import "reflect"
+
+// IsZero reports whether v is the zero value of its type. A nil interface is zero, and a pointer
+// is zero only when it is nil. Common primitive types are checked without reflection; every other
+// type, including structs such as time.Time, arrays and maps, falls back to reflect.Value.IsZero.
+func IsZero(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case int:
+		return v == 0
+	case int8:
+		return v == 0
+	case int16:
+		return v == 0
+	case int32:
+		return v == 0
+	case int64:
+		return v == 0
+	case uint:
+		return v == 0
+	case uint8:
+		return v == 0
+	case uint16:
+		return v == 0
+	case uint32:
+		return v == 0
+	case uint64:
+		return v == 0
+	case uintptr:
+		return v == 0
+	case float32:
+		return v == 0
+	case float64:
+		return v == 0
+	}
+
+	return reflect.ValueOf(v).IsZero()
+}
@@ -0,0 +1,47 @@
+package values
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsZero(t *testing.T) {
+	type point struct{ X, Y int }
+	var nilPtr *int
+	one := 1
+
+	tests := []struct {
+		name string
+		v    any
+		want bool
+	}{
+		{"nil", nil, true},
+		{"empty string", "", true},
+		{"string", "a", false},
+		{"zero int", 0, true},
+		{"int", 3, false},
+		{"zero float", 0.0, true},
+		{"false", false, true},
+		{"nil pointer", nilPtr, true},
+		{"pointer", &one, false},
+		{"empty struct", struct{}{}, true},
+		{"zero time", time.Time{}, true},
+		{"time", time.Now(), false},
+		{"zero struct", point{}, true},
+		{"populated struct", point{X: 1}, false},
+		{"zero array", [3]int{}, true},
+		{"array", [3]int{0, 1, 0}, false},
+		{"nil map", map[string]int(nil), true},
+		{"empty map", map[string]int{}, false},
+		{"nil slice", []int(nil), true},
+		{"empty slice", []int{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsZero(tt.v))
+		})
+	}
+}